@@ -0,0 +1,201 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// ResponseCache holds the responses to recent GET requests of an APIClient,
+// such as for tools that read the same Power resource repeatedly. A GET of a
+// URI read within the TTL is answered from the cache without a request. Once
+// the TTL has passed, a response that carried an ETag is revalidated with
+// If-None-Match, and served from the cache again if the service answers 304
+// Not Modified. The least recently used responses are dropped once the cache
+// is full. Any PATCH, PUT, POST or DELETE of a URI drops its cached
+// response. A ResponseCache is safe for concurrent use.
+type ResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	// order holds the cached responses, most recently used first.
+	order *list.List
+	// now returns the current time, replaceable for tests.
+	now func() time.Time
+}
+
+// cachedResponse is a response held by a ResponseCache.
+type cachedResponse struct {
+	uri        string
+	statusCode int
+	header     http.Header
+	body       []byte
+	etag       string
+	expires    time.Time
+}
+
+// NewResponseCache returns a ResponseCache holding up to size responses,
+// each served without revalidation for ttl. A size below 1 holds a single
+// response.
+func NewResponseCache(size int, ttl time.Duration) *ResponseCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &ResponseCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.order.Len()
+}
+
+// Purge drops all the responses in the cache.
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = make(map[string]*list.Element)
+	rc.order.Init()
+}
+
+// lookup returns the cached response for uri, if any, and whether it is
+// still within its TTL.
+func (rc *ResponseCache) lookup(uri string) (*cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	element, ok := rc.entries[uri]
+	if !ok {
+		return nil, false
+	}
+	rc.order.MoveToFront(element)
+
+	entry := element.Value.(*cachedResponse)
+	return entry, rc.now().Before(entry.expires)
+}
+
+// store caches body as the response for uri, dropping the least recently
+// used response if the cache is full.
+func (rc *ResponseCache) store(uri string, resp *http.Response, body []byte) *cachedResponse {
+	entry := &cachedResponse{
+		uri:        uri,
+		statusCode: resp.StatusCode,
+		header:     resp.Header.Clone(),
+		body:       body,
+		etag:       resp.Header.Get("ETag"),
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry.expires = rc.now().Add(rc.ttl)
+	if element, ok := rc.entries[uri]; ok {
+		element.Value = entry
+		rc.order.MoveToFront(element)
+		return entry
+	}
+
+	rc.entries[uri] = rc.order.PushFront(entry)
+	for rc.order.Len() > rc.size {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cachedResponse).uri)
+	}
+
+	return entry
+}
+
+// revalidated returns entry with its TTL restarted, replacing it in the
+// cache, after the service confirmed it is unchanged.
+func (rc *ResponseCache) revalidated(entry *cachedResponse) *cachedResponse {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	renewed := *entry
+	renewed.expires = rc.now().Add(rc.ttl)
+	if element, ok := rc.entries[entry.uri]; ok && element.Value == entry {
+		element.Value = &renewed
+	}
+
+	return &renewed
+}
+
+// invalidate drops the cached response for uri, ignoring any fragment.
+func (rc *ResponseCache) invalidate(uri string) {
+	uri = strings.SplitN(uri, "#", 2)[0]
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if element, ok := rc.entries[uri]; ok {
+		rc.order.Remove(element)
+		delete(rc.entries, uri)
+	}
+}
+
+// response returns a new response with the cached status, headers and body.
+func (entry *cachedResponse) response() *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(entry.statusCode),
+		StatusCode:    entry.statusCode,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+	}
+}
+
+// cachedGet performs a GET of url through the response cache of the client.
+func (c *APIClient) cachedGet(url string) (*http.Response, error) {
+	entry, fresh := c.cache.lookup(url)
+	if fresh {
+		return entry.response(), nil
+	}
+
+	var headers map[string]string
+	if entry != nil && entry.etag != "" {
+		headers = map[string]string{"If-None-Match": entry.etag}
+	}
+
+	resp, err := c.runRequestWithHeaders(http.MethodGet, url, nil, headers)
+	if err != nil {
+		var serviceErr *common.Error
+		if headers != nil && errors.As(err, &serviceErr) && serviceErr.HTTPReturnedStatusCode == http.StatusNotModified {
+			return c.cache.revalidated(entry).response(), nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if headers != nil && resp.StatusCode == http.StatusNotModified {
+		return c.cache.revalidated(entry).response(), nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cache.store(url, resp, body).response(), nil
+}
@@ -0,0 +1,155 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// etagTransport serves a body with an ETag, answering 304 Not Modified to
+// requests that send a matching If-None-Match.
+type etagTransport struct {
+	mu       sync.Mutex
+	etag     string
+	body     string
+	requests []*http.Request
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests = append(t.requests, req)
+
+	header := http.Header{}
+	if t.etag != "" {
+		header.Set("ETag", t.etag)
+	}
+	if req.Method == http.MethodGet && t.etag != "" && req.Header.Get("If-None-Match") == t.etag {
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Error reading body: %s", err)
+	}
+	return string(body)
+}
+
+// TestClientResponseCache tests serving GETs from the cache within the TTL
+// and revalidating them with the ETag afterwards.
+func TestClientResponseCache(t *testing.T) {
+	transport := &etagTransport{etag: `"1"`, body: `{"Name": "Power"}`}
+	client := newRetryClient(context.Background(), transport, nil)
+
+	now := time.Unix(1000, 0)
+	cache := NewResponseCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+	client.SetResponseCache(cache)
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get("/redfish/v1/Chassis/1/Power")
+		if err != nil {
+			t.Fatalf("Error getting power: %s", err)
+		}
+		if body := readBody(t, resp); body != transport.body {
+			t.Errorf("Invalid body: %s", body)
+		}
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("Expected 1 request within the TTL, got %d", len(transport.requests))
+	}
+
+	now = now.Add(2 * time.Minute)
+	resp, err := client.Get("/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error revalidating power: %s", err)
+	}
+	if body := readBody(t, resp); body != transport.body {
+		t.Errorf("Expected the cached body after 304, got: %s", body)
+	}
+	if len(transport.requests) != 2 || transport.requests[1].Header.Get("If-None-Match") != `"1"` {
+		t.Errorf("Expected a conditional GET, got %d requests", len(transport.requests))
+	}
+
+	// The revalidated response is fresh again
+	if _, err := client.Get("/redfish/v1/Chassis/1/Power"); err != nil || len(transport.requests) != 2 {
+		t.Errorf("Expected the revalidated response to be served, got %d requests: %v", len(transport.requests), err)
+	}
+
+	// Requests with their own headers bypass the cache
+	if _, err := client.GetWithHeaders("/redfish/v1/Chassis/1/Power", map[string]string{"Prefer": "x"}); err != nil || len(transport.requests) != 3 {
+		t.Errorf("Expected a request bypassing the cache, got %d requests: %v", len(transport.requests), err)
+	}
+
+	// Changes drop the cached response
+	if _, err := client.Patch("/redfish/v1/Chassis/1/Power", map[string]string{"Name": "x"}); err != nil {
+		t.Fatalf("Error patching power: %s", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected the cached response to be dropped, have %d", cache.Len())
+	}
+}
+
+// TestResponseCacheEviction tests dropping the least recently used response.
+func TestResponseCacheEviction(t *testing.T) {
+	transport := &etagTransport{body: `{}`}
+	client := newRetryClient(context.Background(), transport, nil)
+	cache := NewResponseCache(2, time.Hour)
+	client.SetResponseCache(cache)
+
+	for _, uri := range []string{"/a", "/b", "/a", "/c", "/a", "/b"} {
+		resp, err := client.Get(uri)
+		if err != nil {
+			t.Fatalf("Error getting %s: %s", uri, err)
+		}
+		resp.Body.Close()
+	}
+
+	// /b is dropped for /c, then /c for /b
+	if len(transport.requests) != 4 || cache.Len() != 2 {
+		t.Errorf("Expected 4 requests and 2 cached responses, got %d and %d", len(transport.requests), cache.Len())
+	}
+}
+
+// TestClientWithoutResponseCache tests that every GET is sent by default.
+func TestClientWithoutResponseCache(t *testing.T) {
+	transport := &etagTransport{etag: `"1"`, body: `{}`}
+	client := newRetryClient(context.Background(), transport, nil)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get("/redfish/v1/Chassis/1/Power")
+		if err != nil {
+			t.Fatalf("Error getting power: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if len(transport.requests) != 2 || transport.requests[1].Header.Get("If-None-Match") != "" {
+		t.Errorf("Expected 2 unconditional requests, got %d", len(transport.requests))
+	}
+}
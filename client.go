@@ -26,7 +26,8 @@ import (
 	"github.com/ciferlu1024/gofish/redfish"
 )
 
-const userAgent = "gofish/1.0"
+// defaultUserAgent is sent with requests unless ClientConfig.UserAgent is set.
+const defaultUserAgent = "gofish/1.0"
 const applicationJSON = "application/json"
 
 // APIClient represents a connection to a Redfish/Swordfish enabled service
@@ -49,6 +50,24 @@ type APIClient struct {
 
 	// dumpWriter will receive HTTP dumps if non-nil.
 	dumpWriter io.Writer
+
+	// userAgent is the User-Agent header sent with each request.
+	userAgent string
+
+	// strictSchema makes schema violations errors rather than tolerated.
+	strictSchema bool
+
+	// useNumber keeps the original text of numbers in responses.
+	useNumber bool
+
+	// retryPolicy controls retrying failed requests, if set.
+	retryPolicy *RetryPolicy
+
+	// cache holds the responses to recent GET requests, if set.
+	cache *ResponseCache
+
+	// requestTimeout bounds the time taken by each request, if set.
+	requestTimeout time.Duration
 }
 
 // Session holds the session ID and auth token needed to identify an
@@ -88,6 +107,40 @@ type ClientConfig struct {
 
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
 	BasicAuth bool
+
+	// UserAgent is the optional User-Agent header to identify the client to
+	// the service. It defaults to gofish/<version>.
+	UserAgent string
+
+	// StrictSchema makes responses that violate the Redfish schema, such as
+	// an object where an array is required, fail with an error wrapping
+	// common.ErrSchemaViolation instead of being tolerated. This is useful
+	// for conformance testing.
+	StrictSchema bool
+
+	// UseNumber keeps the original text of the numbers in responses where
+	// supported, such as by Power.Number, so conformance tools can tell
+	// whether a service sent 1 or 1.0. Decoded fields are unaffected.
+	UseNumber bool
+
+	// RetryPolicy is the optional policy for retrying requests that fail
+	// with a transient error, such as a 503 from a busy service. Requests
+	// are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+
+	// ResponseCache is the optional cache of responses to GET requests,
+	// created with NewResponseCache. Every GET goes to the service if it is
+	// nil.
+	ResponseCache *ResponseCache
+
+	// RequestTimeout is the optional limit on the time taken by each request,
+	// such as to give up on an unresponsive service without passing a
+	// context with a deadline. It bounds the whole request, including any
+	// retries under the RetryPolicy and their delays, rather than each
+	// attempt, and ends when the body of the response is closed. The
+	// deadline of the context the client was created with still applies if
+	// it is earlier. Zero means no limit.
+	RequestTimeout time.Duration
 }
 
 // setupClientWithConfig setups the client using the client config
@@ -100,6 +153,14 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 		endpoint:   config.Endpoint,
 		dumpWriter: config.DumpWriter,
 		ctx:        ctx,
+		userAgent:  config.UserAgent,
+
+		strictSchema: config.StrictSchema,
+		useNumber:    config.UseNumber,
+		retryPolicy:  config.RetryPolicy,
+		cache:        config.ResponseCache,
+
+		requestTimeout: config.RequestTimeout,
 	}
 
 	if config.TLSHandshakeTimeout == 0 {
@@ -266,6 +327,14 @@ func (c *APIClient) Get(url string) (*http.Response, error) {
 	return c.GetWithHeaders(url, nil)
 }
 
+// GetWithContext performs a GET request against the Redfish service, using
+// ctx for the request instead of the context of the client.
+func (c *APIClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	withContext := *c
+	withContext.ctx = ctx
+	return withContext.Get(url)
+}
+
 // GetWithHeaders performs a GET request against the Redfish service but allowing custom headers
 func (c *APIClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
 	relativePath := url
@@ -273,6 +342,12 @@ func (c *APIClient) GetWithHeaders(url string, customHeaders map[string]string)
 		relativePath = common.DefaultServiceRoot
 	}
 
+	// Requests with their own headers, such as conditional GETs, bypass the
+	// cache
+	if c.cache != nil && len(customHeaders) == 0 {
+		return c.cachedGet(relativePath)
+	}
+
 	return c.runRequestWithHeaders(http.MethodGet, relativePath, nil, customHeaders)
 }
 
@@ -398,14 +473,83 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 		return nil, common.ConstructError(0, []byte("unable to execute request, no target provided"))
 	}
 
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if c.cache != nil && method != http.MethodGet && method != http.MethodHead {
+		c.cache.invalidate(url)
+	}
+
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		resp, err := c.retryRawRequest(ctx, method, url, payloadBuffer, contentType, customHeaders)
+		if err != nil || resp.Body == nil {
+			cancel()
+			return resp, err
+		}
+
+		// The deadline has to cover reading the body as well
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	}
+
+	return c.retryRawRequest(ctx, method, url, payloadBuffer, contentType, customHeaders)
+}
+
+// cancelOnClose is a response body that cancels the context of its request
+// when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context of the request.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryRawRequest performs a REST call, retrying it as allowed by the retry
+// policy until ctx is done.
+func (c *APIClient) retryRawRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doRawRequest(ctx, method, url, payloadBuffer, contentType, customHeaders)
+		if err == nil || !c.retryPolicy.shouldRetry(ctx, method, attempt, err) {
+			return resp, err
+		}
+
+		// The payload has to be sent again from the start
+		if payloadBuffer != nil {
+			if _, seekErr := payloadBuffer.Seek(0, io.SeekStart); seekErr != nil {
+				return resp, err
+			}
+		}
+
+		if !c.retryPolicy.wait(ctx, attempt) {
+			return resp, err
+		}
+		common.GetLogger().Debugf("gofish: retrying %s %s after attempt %d: %v", method, url, attempt, err)
+	}
+}
+
+// doRawRequest performs a single attempt of a REST call
+func (c *APIClient) doRawRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
 	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
-	req, err := http.NewRequestWithContext(c.ctx, method, endpoint, payloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payloadBuffer)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add common headers
-	req.Header.Set("User-Agent", userAgent)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	} else {
+		req.Header.Set("User-Agent", defaultUserAgent)
+	}
 	req.Header.Set("Accept", applicationJSON)
 
 	// Add custom headers
@@ -487,7 +631,6 @@ func (c *APIClient) dumpRequest(req *http.Request) error {
 	d = append(d, '\n')
 	_, err = c.dumpWriter.Write(d)
 	if err != nil {
-		fmt.Println("**************dump request panic错误！")
 		panic(err)
 	}
 
@@ -504,7 +647,6 @@ func (c *APIClient) dumpResponse(resp *http.Response) error {
 	d = append(d, '\n')
 	_, err = c.dumpWriter.Write(d)
 	if err != nil {
-		fmt.Println("**************dump response panic错误！")
 		panic(err)
 	}
 
@@ -519,6 +661,42 @@ func (c *APIClient) Logout() {
 	}
 }
 
+// SetUserAgent sets the User-Agent header sent with subsequent requests. An
+// empty value restores the default.
+func (c *APIClient) SetUserAgent(userAgent string) {
+	c.userAgent = userAgent
+}
+
+// IsStrictSchema reports whether the client rejects responses that violate
+// the Redfish schema.
+func (c *APIClient) IsStrictSchema() bool {
+	return c.strictSchema
+}
+
+// SetRetryPolicy sets the policy for retrying subsequent requests that fail
+// with a transient error. Passing nil disables retries.
+func (c *APIClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetRequestTimeout sets the limit on the time taken by each subsequent
+// request, as described for ClientConfig.RequestTimeout. Zero means no limit.
+func (c *APIClient) SetRequestTimeout(timeout time.Duration) {
+	c.requestTimeout = timeout
+}
+
+// SetResponseCache sets the cache for the responses to subsequent GET
+// requests. Passing nil disables caching.
+func (c *APIClient) SetResponseCache(cache *ResponseCache) {
+	c.cache = cache
+}
+
+// IsUseNumber reports whether the client keeps the original text of numbers
+// in responses.
+func (c *APIClient) IsUseNumber() bool {
+	return c.useNumber
+}
+
 // SetDumpWriter sets the client the DumpWriter dynamically
 func (c *APIClient) SetDumpWriter(writer io.Writer) {
 	c.dumpWriter = writer
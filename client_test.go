@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/ciferlu1024/gofish/common"
+	"github.com/ciferlu1024/gofish/redfish"
 )
 
 const (
@@ -200,3 +201,92 @@ func TestClientRunRawRequestNoURL(t *testing.T) {
 		t.Errorf("Unexpected error response: %s", err.Error())
 	}
 }
+
+// TestClientUserAgent tests that power requests carry the configured
+// User-Agent.
+func TestClientUserAgent(t *testing.T) {
+	agents := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents[r.Method+" "+r.URL.Path] = r.UserAgent()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Chassis/1/Power":
+			w.Write([]byte(`{
+				"@odata.id": "/redfish/v1/Chassis/1/Power",
+				"@odata.type": "#Power.v1_5_3.Power",
+				"PowerSupplies": [{
+					"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+					"MemberId": "0",
+					"IndicatorLED": "Off"
+				}]
+			}`)) // nolint
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"@odata.id": "/redfish/v1/"}`)) // nolint
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), UserAgent: "fleet-audit/2.1"})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	power, err := redfish.GetPower(client, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	power.PowerSupplies[0].IndicatorLED = common.LitIndicatorLED
+	if err := power.PowerSupplies[0].Update(); err != nil {
+		t.Fatalf("Error updating power supply: %s", err)
+	}
+
+	for _, request := range []string{"GET /redfish/v1/Chassis/1/Power", "PATCH /redfish/v1/Chassis/1/Power"} {
+		if agents[request] != "fleet-audit/2.1" {
+			t.Errorf("%s: unexpected User-Agent %q", request, agents[request])
+		}
+	}
+
+	client.SetUserAgent("")
+	if _, err := redfish.GetPower(client, "/redfish/v1/Chassis/1/Power"); err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if agents["GET /redfish/v1/Chassis/1/Power"] != defaultUserAgent {
+		t.Errorf("Expected the default User-Agent, got %q", agents["GET /redfish/v1/Chassis/1/Power"])
+	}
+}
+
+// TestClientGetWithContext tests that a GET can be cancelled with its own
+// context.
+func TestClientGetWithContext(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/Chassis/1/Power" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+		w.Write([]byte(`{"@odata.id": "/redfish/v1/"}`)) // nolint
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = redfish.GetPowerWithContext(ctx, client, "/redfish/v1/Chassis/1/Power")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got: %v", err)
+	}
+
+	resp, err := client.Get("/redfish/v1/")
+	if err != nil {
+		t.Fatalf("Expected the client context to be unaffected: %s", err)
+	}
+	resp.Body.Close()
+}
@@ -5,8 +5,10 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
 // Collection represents a collection of entity references.
@@ -44,10 +46,16 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 
 // GetCollection retrieves a collection from the service.
 func GetCollection(c Client, uri string) (*Collection, error) {
-	fmt.Println("*********当前gofish common collection的getcollection函数link url: ", uri)
-	resp, err := c.Get(uri)
+	return GetCollectionWithContext(context.Background(), c, uri)
+}
+
+// GetCollectionWithContext retrieves a collection from the service, giving up
+// when ctx is done.
+func GetCollectionWithContext(ctx context.Context, c Client, uri string) (*Collection, error) {
+	GetLogger().Debugf("gofish: getting collection %s", uri)
+	resp, err := GetWithContext(ctx, c, uri)
 	if err != nil {
-		fmt.Println("**********gofish common collection的getcollection函数link url失败！")
+		GetLogger().Errorf("gofish: getting collection %s: %v", uri, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -94,9 +102,60 @@ func (cr *CollectionError) Error() string {
 
 	errorsJSON, err := json.Marshal(entityErrors)
 	if err != nil {
-		fmt.Println("*********************collection.go panic错误")
 		panic(err)
 	}
 
 	return fmt.Sprintf("failed to retrieve some items: %s", errorsJSON)
 }
+
+// DefaultResolveWorkers is how many links ResolveLinks fetches at a time if
+// no other number is given.
+const DefaultResolveWorkers = 4
+
+// ResolveLinks calls fetch for each of the links, up to workers at a time,
+// such as to get the resources a list of links refers to. Fetch is given the
+// index of the link so it can store what it gets in a slice of the caller's
+// type, keeping the order of the links:
+//
+//	powers := make([]*redfish.Power, len(links))
+//	err := common.ResolveLinks(links, 0, func(i int, link string) (err error) {
+//		powers[i], err = redfish.GetPower(c, link)
+//		return err
+//	})
+//
+// The links fetch fails for are reported together as a CollectionError once
+// all have been tried. Fewer than one worker means DefaultResolveWorkers.
+func ResolveLinks(links []string, workers int, fetch func(index int, link string) error) error {
+	if workers <= 0 {
+		workers = DefaultResolveWorkers
+	}
+
+	errs := make([]error, len(links))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i, link := range links {
+		wg.Add(1)
+		go func(i int, link string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			errs[i] = fetch(i, link)
+		}(i, link)
+	}
+	wg.Wait()
+
+	collectionError := NewCollectionError()
+	for i, link := range links {
+		if errs[i] != nil {
+			collectionError.Failures[link] = errs[i]
+		}
+	}
+
+	if collectionError.Empty() {
+		return nil
+	}
+
+	return collectionError
+}
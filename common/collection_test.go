@@ -6,9 +6,12 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var collectionBody = strings.NewReader(
@@ -55,3 +58,49 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+// TestResolveLinks tests fetching links concurrently in a stable order.
+func TestResolveLinks(t *testing.T) {
+	links := []string{"/a", "/b", "/missing", "/c", "/d", "/e"}
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	names := make([]string, len(links))
+	err := ResolveLinks(links, 2, func(i int, link string) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		// Finish out of order
+		time.Sleep(time.Duration(len(links)-i) * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if link == "/missing" {
+			return errors.New("not found")
+		}
+		names[i] = strings.TrimPrefix(link, "/")
+		return nil
+	})
+
+	if strings.Join(names, ",") != "a,b,,c,d,e" {
+		t.Errorf("Invalid order: %v", names)
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 fetches at a time, got %d", maxRunning)
+	}
+
+	var collectionErr *CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 || collectionErr.Failures["/missing"] == nil {
+		t.Errorf("Expected the missing link to be reported, got: %v", err)
+	}
+
+	if err := ResolveLinks(nil, 0, nil); err != nil {
+		t.Errorf("Expected no error without links, got: %v", err)
+	}
+}
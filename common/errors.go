@@ -0,0 +1,191 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrSchemaViolation is wrapped by errors reporting a response that does not
+// conform to the Redfish schema, returned by clients configured for strict
+// schema handling.
+var ErrSchemaViolation = errors.New("response violates the Redfish schema")
+
+// ErrUpdateNotSupported is wrapped by errors reporting that a property cannot
+// be changed on the service, returned before any request is made.
+var ErrUpdateNotSupported = errors.New("update not supported")
+
+// ErrNoClient is returned by requests for resources that were decoded from
+// data already read, such as by redfish.ParsePower, rather than fetched with
+// a Client.
+var ErrNoClient = errors.New("resource has no client to reach the service")
+
+// TransportError indicates a request could not be completed, such as when a
+// connection fails or times out. These failures are generally safe to retry.
+type TransportError struct {
+	// URI is the resource that was being requested.
+	URI string
+	// Err is the underlying failure.
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("request for %s failed: %v", e.URI, e.Err)
+}
+
+// Unwrap returns the underlying failure.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError indicates a response was received but its body could not be
+// decoded into the expected resource. Retrying will usually not help.
+type ParseError struct {
+	// URI is the resource that was being requested.
+	URI string
+	// Err is the underlying decode failure.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.URI == "" {
+		return fmt.Sprintf("unable to parse resource: %v", e.Err)
+	}
+	return fmt.Sprintf("unable to parse %s: %v", e.URI, e.Err)
+}
+
+// Unwrap returns the underlying decode failure.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// RedfishError indicates the service responded with an error status.
+type RedfishError struct {
+	// URI is the resource that was being requested.
+	URI string
+	// Err is the error returned by the service.
+	Err *Error
+}
+
+func (e *RedfishError) Error() string {
+	return fmt.Sprintf("service returned an error for %s: %v", e.URI, e.Err)
+}
+
+// Unwrap returns the error returned by the service.
+func (e *RedfishError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code returned by the service.
+func (e *RedfishError) StatusCode() int {
+	return e.Err.HTTPReturnedStatusCode
+}
+
+// ExtendedInfo returns the @Message.ExtendedInfo messages describing the
+// error, such as which property could not be written.
+func (e *RedfishError) ExtendedInfo() []ErrExtendedInfo {
+	return e.Err.ExtendedInfos
+}
+
+// MessageIDs returns the message registry identifiers reported for the
+// error, starting with the error code.
+func (e *RedfishError) MessageIDs() []string {
+	var ids []string
+	if e.Err.Code != "" {
+		ids = append(ids, e.Err.Code)
+	}
+	for _, info := range e.Err.ExtendedInfos {
+		if info.MessageID != "" {
+			ids = append(ids, info.MessageID)
+		}
+	}
+	return ids
+}
+
+// HasMessageID reports whether the service reported the message id, such as
+// "Base.1.0.PropertyNotWritable", in the error code or extended info. The
+// registry version is ignored, so "Base.1.8.PropertyNotWritable" also
+// matches.
+func (e *RedfishError) HasMessageID(id string) bool {
+	key := messageKey(id)
+	for _, reported := range e.MessageIDs() {
+		if reported == id || messageKey(reported) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// messageKey strips the registry version from a message id, leaving the
+// registry name and message key.
+func messageKey(id string) string {
+	fields := strings.Split(id, ".")
+	if len(fields) < 2 {
+		return id
+	}
+	return fields[0] + "." + fields[len(fields)-1]
+}
+
+// ClassifyError wraps an error from requesting and decoding uri in a
+// *RedfishError, *ParseError or *TransportError so callers can use errors.As
+// to decide how to handle it. Errors that are already classified are
+// returned unchanged.
+func ClassifyError(uri string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var transportErr *TransportError
+	var parseErr *ParseError
+	var redfishErr *RedfishError
+	if errors.As(err, &transportErr) || errors.As(err, &parseErr) || errors.As(err, &redfishErr) {
+		return err
+	}
+
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return &RedfishError{URI: uri, Err: serviceErr}
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var resourceTypeErr *ResourceTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) ||
+		errors.As(err, &resourceTypeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &ParseError{URI: uri, Err: err}
+	}
+
+	return &TransportError{URI: uri, Err: err}
+}
+
+// ClassifyServiceError wraps an error the service reported for a request of
+// uri in a *RedfishError, like ClassifyError. Other errors, such as local
+// validation failures or ErrNoClient, are returned unchanged.
+func ClassifyServiceError(uri string, err error) error {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return ClassifyError(uri, err)
+	}
+	return err
+}
+
+// DecodeWarning records an element of an array property that could not be
+// decoded and was skipped, so that the rest of the resource is still usable.
+type DecodeWarning struct {
+	// Property is the name of the array property, such as "PowerSupplies".
+	Property string
+	// Index is the position of the element within the array.
+	Index int
+	// Err is the decode failure.
+	Err error
+}
+
+func (w DecodeWarning) String() string {
+	return fmt.Sprintf("%s[%d]: %v", w.Property, w.Index, w.Err)
+}
@@ -0,0 +1,84 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+var propertyNotWritableBody = `{
+		"error": {
+			"code": "Base.1.8.GeneralError",
+			"message": "A general error has occurred.",
+			"@Message.ExtendedInfo": [
+				{
+					"MessageId": "Base.1.8.PropertyNotWritable",
+					"Message": "The property IndicatorLED is a read only property and cannot be assigned a value.",
+					"MessageArgs": ["IndicatorLED"],
+					"Severity": "Warning",
+					"Resolution": "Remove the property from the request body and resubmit the request if the operation failed."
+				}
+			]
+		}
+	}`
+
+// TestRedfishErrorExtendedInfo tests reading the extended info of a service error.
+func TestRedfishErrorExtendedInfo(t *testing.T) {
+	err := ClassifyError("/redfish/v1/Chassis/1/Power",
+		ConstructError(http.StatusBadRequest, []byte(propertyNotWritableBody)))
+
+	var redfishErr *RedfishError
+	if !errors.As(err, &redfishErr) {
+		t.Fatalf("Expected *RedfishError, got: %T", err)
+	}
+
+	if redfishErr.StatusCode() != http.StatusBadRequest {
+		t.Errorf("Invalid status code: %d", redfishErr.StatusCode())
+	}
+
+	info := redfishErr.ExtendedInfo()
+	if len(info) != 1 || info[0].MessageArgs[0] != "IndicatorLED" ||
+		info[0].Resolution == "" {
+		t.Errorf("Invalid extended info: %v", info)
+	}
+
+	ids := redfishErr.MessageIDs()
+	if len(ids) != 2 || ids[0] != "Base.1.8.GeneralError" {
+		t.Errorf("Invalid message ids: %v", ids)
+	}
+
+	if !redfishErr.HasMessageID("Base.1.8.PropertyNotWritable") {
+		t.Error("Expected exact message id to match")
+	}
+
+	if !redfishErr.HasMessageID("Base.1.0.PropertyNotWritable") {
+		t.Error("Expected message id of another registry version to match")
+	}
+
+	if redfishErr.HasMessageID("Base.1.0.PropertyUnknown") {
+		t.Error("Expected unrelated message id not to match")
+	}
+}
+
+// TestClassifyServiceError tests that only errors reported by the service
+// are classified.
+func TestClassifyServiceError(t *testing.T) {
+	uri := "/redfish/v1/Chassis/1/Power"
+
+	var redfishErr *RedfishError
+	err := ClassifyServiceError(uri, ConstructError(http.StatusBadRequest, []byte(propertyNotWritableBody)))
+	if !errors.As(err, &redfishErr) || redfishErr.URI != uri {
+		t.Errorf("Expected a RedfishError, got: %v", err)
+	}
+
+	if err := ClassifyServiceError(uri, ErrNoClient); err != ErrNoClient {
+		t.Errorf("Expected ErrNoClient unchanged, got: %v", err)
+	}
+	if err := ClassifyServiceError(uri, nil); err != nil {
+		t.Errorf("Expected nil, got: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlexFloat is a number that may also be given as a JSON string, such as
+// "123.4", as some services do for their readings. An empty string or null
+// decodes as zero.
+type FlexFloat float64
+
+// UnmarshalJSON unmarshals a FlexFloat from a JSON number or string.
+func (f *FlexFloat) UnmarshalJSON(b []byte) error {
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*f = 0
+			return nil
+		}
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q: %w", s, err)
+		}
+		*f = FlexFloat(value)
+		return nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*f = FlexFloat(value)
+	return nil
+}
+
+// Float64 returns the value as a float64.
+func (f FlexFloat) Float64() float64 {
+	return float64(f)
+}
@@ -0,0 +1,43 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFlexFloat tests decoding numbers given as JSON numbers or strings.
+func TestFlexFloat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{`123.4`, 123.4},
+		{`"123.4"`, 123.4},
+		{`" 800 "`, 800},
+		{`""`, 0},
+		{`null`, 0},
+		{`-48`, -48},
+	}
+
+	for _, test := range tests {
+		var value FlexFloat
+		if err := json.Unmarshal([]byte(test.input), &value); err != nil {
+			t.Errorf("Error decoding %s: %s", test.input, err)
+			continue
+		}
+		if value.Float64() != test.expected {
+			t.Errorf("Decoding %s, expected %v, got %v", test.input, test.expected, value)
+		}
+	}
+
+	for _, input := range []string{`"watts"`, `true`, `{}`} {
+		var value FlexFloat
+		if err := json.Unmarshal([]byte(input), &value); err == nil {
+			t.Errorf("Expected an error decoding %s", input)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"log"
+	"sync"
+)
+
+// Logger receives the diagnostic messages of the library. By default they
+// are discarded; call SetLogger to receive them.
+type Logger interface {
+	// Debugf logs a message tracing the operation of the library.
+	Debugf(format string, args ...interface{})
+	// Warnf logs a message about something unexpected that the library
+	// tolerated, such as a nonstandard value from the service.
+	Warnf(format string, args ...interface{})
+	// Errorf logs a message about a failure.
+	Errorf(format string, args ...interface{})
+}
+
+// nopLogger discards all messages.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...interface{}) {}
+func (nopLogger) Warnf(string, ...interface{})  {}
+func (nopLogger) Errorf(string, ...interface{}) {}
+
+// stdLogger adapts a log.Logger to Logger.
+type stdLogger struct {
+	logger *log.Logger
+}
+
+// NewStdLogger returns a Logger that writes all messages to l, with the
+// level as a prefix. If l is nil, the standard logger is used.
+func NewStdLogger(l *log.Logger) Logger {
+	if l == nil {
+		l = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+	return &stdLogger{logger: l}
+}
+
+func (l *stdLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Printf("DEBUG "+format, args...)
+}
+
+func (l *stdLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Printf("WARN "+format, args...)
+}
+
+func (l *stdLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Printf("ERROR "+format, args...)
+}
+
+var (
+	loggerMu sync.RWMutex
+	logger   Logger = nopLogger{}
+)
+
+// SetLogger sets the Logger to receive the diagnostic messages of the
+// library. Passing nil discards them again.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = l
+}
+
+// GetLogger returns the Logger set with SetLogger.
+func GetLogger() Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return logger
+}
@@ -0,0 +1,81 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordingLogger keeps the messages it receives.
+type recordingLogger struct {
+	debug []string
+	warn  []string
+	error []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.debug = append(l.debug, format)
+}
+
+func (l *recordingLogger) Warnf(format string, args ...interface{}) {
+	l.warn = append(l.warn, format)
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.error = append(l.error, format)
+}
+
+// TestSetLogger tests routing diagnostic messages to a Logger.
+func TestSetLogger(t *testing.T) {
+	if _, ok := GetLogger().(nopLogger); !ok {
+		t.Errorf("Expected messages to be discarded by default, got %T", GetLogger())
+	}
+
+	recorder := &recordingLogger{}
+	SetLogger(recorder)
+	defer SetLogger(nil)
+
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {&http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+			}},
+		},
+	}
+	if _, err := GetCollection(testClient, "/redfish/v1/Chassis"); err == nil {
+		t.Error("Expected an error for the missing collection")
+	}
+	if len(recorder.debug) != 1 || len(recorder.error) != 1 {
+		t.Errorf("Expected one debug and one error message, got %v and %v", recorder.debug, recorder.error)
+	}
+
+	SetLogger(nil)
+	if _, ok := GetLogger().(nopLogger); !ok {
+		t.Errorf("Expected a nil Logger to discard messages, got %T", GetLogger())
+	}
+}
+
+// TestNewStdLogger tests writing messages through a log.Logger.
+func TestNewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0))
+	logger.Debugf("getting %s", "/redfish/v1")
+	logger.Warnf("unexpected %q", "Cpu")
+	logger.Errorf("failed: %d", 404)
+
+	expected := "DEBUG getting /redfish/v1\nWARN unexpected \"Cpu\"\nERROR failed: 404\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "DEBUG") {
+		t.Error("Expected the level as a prefix")
+	}
+}
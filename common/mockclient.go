@@ -0,0 +1,261 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// MockResponse is a canned response served by a MockClient.
+type MockResponse struct {
+	// StatusCode is the HTTP status code. Zero means 200 OK.
+	StatusCode int
+	// Body is the response body, such as the JSON of a resource.
+	Body string
+	// Header holds the response headers, such as ETag.
+	Header http.Header
+}
+
+// MockCall records a request made through a MockClient.
+type MockCall struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URI requested.
+	URL string
+	// Body is the JSON encoded payload, or empty if there was none.
+	Body string
+	// Headers holds the custom headers sent with the request.
+	Headers map[string]string
+}
+
+// MockClient is a Client for unit tests that serves canned responses by URI
+// instead of connecting to a service. Unlike TestClient, responses do not
+// depend on the order of the requests, so code making requests in any order
+// or concurrently can be tested. GET requests for unknown URIs fail with 404
+// Not Found, while other requests succeed with 204 No Content unless a
+// response is set for them. HEAD requests are answered as GET requests are,
+// without the body, unless a response is set for them. It is safe for
+// concurrent use.
+type MockClient struct {
+	mu        sync.Mutex
+	responses map[string]MockResponse
+	calls     []MockCall
+}
+
+// NewMockClient returns a MockClient with no responses.
+func NewMockClient() *MockClient {
+	return &MockClient{responses: make(map[string]MockResponse)}
+}
+
+// mockKey returns the key of the response for a method and URI.
+func mockKey(method, uri string) string {
+	return method + " " + uri
+}
+
+// SetResponse sets the response to requests with the method for the URI.
+func (c *MockClient) SetResponse(method, uri string, resp MockResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[mockKey(method, uri)] = resp
+}
+
+// SetBody sets the body returned for GET requests of the URI.
+func (c *MockClient) SetBody(uri, body string) {
+	c.SetResponse(http.MethodGet, uri, MockResponse{Body: body})
+}
+
+// SetError makes requests with the method for the URI fail with the status
+// code, as a service would. The body may be a Redfish error response.
+func (c *MockClient) SetError(method, uri string, statusCode int, body string) {
+	c.SetResponse(method, uri, MockResponse{StatusCode: statusCode, Body: body})
+}
+
+// Calls returns the requests made through the client, in order.
+func (c *MockClient) Calls() []MockCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MockCall(nil), c.calls...)
+}
+
+// PatchBodies returns the payloads of the PATCH requests made for the URI,
+// in order.
+func (c *MockClient) PatchBodies(uri string) []string {
+	return c.bodies(http.MethodPatch, uri)
+}
+
+// PostBodies returns the payloads of the POST requests made for the URI,
+// such as an action target, in order.
+func (c *MockClient) PostBodies(uri string) []string {
+	return c.bodies(http.MethodPost, uri)
+}
+
+// bodies returns the payloads of the requests with the method made for the
+// URI, in order.
+func (c *MockClient) bodies(method, uri string) []string {
+	var result []string
+	for _, call := range c.Calls() {
+		if call.Method == method && call.URL == uri {
+			result = append(result, call.Body)
+		}
+	}
+	return result
+}
+
+// do records a request and returns its canned response.
+func (c *MockClient) do(method, url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	var body string
+	switch p := payload.(type) {
+	case nil:
+	case map[string]io.Reader:
+		// Multipart payloads are not recorded
+	case string:
+		body = p
+	default:
+		b, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+
+	c.mu.Lock()
+	c.calls = append(c.calls, MockCall{Method: method, URL: url, Body: body, Headers: customHeaders})
+	c.mu.Unlock()
+
+	return c.respond(method, url)
+}
+
+// respond returns the canned response to a request with the method for the
+// URI.
+func (c *MockClient) respond(method, url string) (*http.Response, error) {
+	c.mu.Lock()
+	mock, ok := c.responses[mockKey(method, url)]
+	if !ok {
+		// Fall back to the response for the URI without its query
+		mock, ok = c.responses[mockKey(method, strings.SplitN(url, "?", 2)[0])]
+	}
+	c.mu.Unlock()
+
+	if !ok && method == http.MethodHead {
+		resp, err := c.respond(http.MethodGet, url)
+		if resp != nil {
+			resp.Body = io.NopCloser(strings.NewReader(""))
+		}
+		return resp, err
+	}
+
+	switch {
+	case ok && mock.StatusCode == 0:
+		mock.StatusCode = http.StatusOK
+	case !ok && method == http.MethodGet:
+		mock = MockResponse{StatusCode: http.StatusNotFound, Body: `{"error": {"code": "Base.1.0.ResourceMissingAtURI"}}`}
+	case !ok:
+		mock = MockResponse{StatusCode: http.StatusNoContent}
+	}
+
+	if mock.StatusCode < 200 || mock.StatusCode > 299 {
+		return nil, ConstructError(mock.StatusCode, []byte(mock.Body))
+	}
+
+	header := http.Header{}
+	for k, v := range mock.Header {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		Status:        http.StatusText(mock.StatusCode),
+		StatusCode:    mock.StatusCode,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(mock.Body)),
+		ContentLength: int64(len(mock.Body)),
+	}, nil
+}
+
+// Get performs a GET request against the mock service.
+func (c *MockClient) Get(url string) (*http.Response, error) {
+	return c.do(http.MethodGet, url, nil, nil)
+}
+
+// GetWithContext performs a GET request against the mock service, failing
+// if ctx is already done.
+func (c *MockClient) GetWithContext(ctx context.Context, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Get(url)
+}
+
+// GetWithHeaders performs a GET request against the mock service.
+func (c *MockClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodGet, url, nil, customHeaders)
+}
+
+// Post performs a Post request against the mock service.
+func (c *MockClient) Post(url string, payload interface{}) (*http.Response, error) {
+	return c.do(http.MethodPost, url, payload, nil)
+}
+
+// PostWithHeaders performs a Post request against the mock service.
+func (c *MockClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodPost, url, payload, customHeaders)
+}
+
+// PostMultipart performs a Post request against the mock service.
+func (c *MockClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.do(http.MethodPost, url, payload, nil)
+}
+
+// PostMultipartWithHeaders performs a Post request against the mock service.
+func (c *MockClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodPost, url, payload, customHeaders)
+}
+
+// Patch performs a Patch request against the mock service.
+func (c *MockClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return c.do(http.MethodPatch, url, payload, nil)
+}
+
+// PatchWithHeaders performs a Patch request against the mock service.
+func (c *MockClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodPatch, url, payload, customHeaders)
+}
+
+// Put performs a Put request against the mock service.
+func (c *MockClient) Put(url string, payload interface{}) (*http.Response, error) {
+	return c.do(http.MethodPut, url, payload, nil)
+}
+
+// PutWithHeaders performs a Put request against the mock service.
+func (c *MockClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodPut, url, payload, customHeaders)
+}
+
+// Delete performs a Delete request against the mock service.
+func (c *MockClient) Delete(url string) (*http.Response, error) {
+	return c.do(http.MethodDelete, url, nil, nil)
+}
+
+// DeleteWithHeaders performs a Delete request against the mock service.
+func (c *MockClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return c.do(http.MethodDelete, url, nil, customHeaders)
+}
+
+// RunRawRequestWithHeaders performs a request with any HTTP method against
+// the mock service.
+func (c *MockClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	var payload interface{}
+	if payloadBuffer != nil {
+		body, err := io.ReadAll(payloadBuffer)
+		if err != nil {
+			return nil, err
+		}
+		payload = string(body)
+	}
+	return c.do(method, url, payload, customHeaders)
+}
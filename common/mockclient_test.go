@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// TestMockClient tests serving canned responses and recording requests.
+func TestMockClient(t *testing.T) {
+	c := NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1", `{"Id": "1"}`)
+	c.SetResponse(http.MethodGet, "/redfish/v1/Chassis/1/Power", MockResponse{
+		Body:   `{"Id": "Power"}`,
+		Header: http.Header{"Etag": []string{`W/"1"`}},
+	})
+	c.SetError(http.MethodPatch, "/redfish/v1/Chassis/2", http.StatusBadRequest, propertyNotWritableBody)
+
+	resp, err := c.Get("/redfish/v1/Chassis/1")
+	if err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != `{"Id": "1"}` {
+		t.Errorf("Invalid response: %d %s", resp.StatusCode, body)
+	}
+
+	// The query is ignored if there is no response for it
+	resp, err = c.Get("/redfish/v1/Chassis/1/Power?$select=PowerControl")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if resp.Header.Get("ETag") != `W/"1"` {
+		t.Errorf("Invalid ETag: %s", resp.Header.Get("ETag"))
+	}
+
+	_, err = c.Get("/redfish/v1/Chassis/3")
+	var serviceErr *Error
+	if !errors.As(err, &serviceErr) || serviceErr.HTTPReturnedStatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown URI, got: %v", err)
+	}
+
+	_, err = c.PatchWithHeaders("/redfish/v1/Chassis/1", map[string]string{"IndicatorLED": "Lit"},
+		map[string]string{"If-Match": "*"})
+	if err != nil {
+		t.Errorf("Error patching chassis: %s", err)
+	}
+
+	_, err = c.Patch("/redfish/v1/Chassis/2", map[string]string{"AssetTag": "x"})
+	redfishErr, ok := ClassifyError("/redfish/v1/Chassis/2", err).(*RedfishError)
+	if !ok || !redfishErr.HasMessageID("Base.1.8.PropertyNotWritable") {
+		t.Errorf("Expected the simulated error, got: %v", err)
+	}
+
+	patches := c.PatchBodies("/redfish/v1/Chassis/1")
+	if len(patches) != 1 || patches[0] != `{"IndicatorLED":"Lit"}` {
+		t.Errorf("Invalid patch bodies: %v", patches)
+	}
+
+	calls := c.Calls()
+	if len(calls) != 5 || calls[3].Headers["If-Match"] != "*" {
+		t.Errorf("Invalid calls: %+v", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GetWithContext(ctx, c, "/redfish/v1/Chassis/1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled request, got: %v", err)
+	}
+}
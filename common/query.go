@@ -0,0 +1,98 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// queryParam is a single query parameter. Flag parameters, such as only,
+// have no value.
+type queryParam struct {
+	key   string
+	value string
+	flag  bool
+}
+
+// Query builds the query string of a Redfish GET request. Parameters are
+// encoded in the order they are added.
+type Query struct {
+	params []queryParam
+}
+
+// NewQuery returns an empty Query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// WithFilter adds a $filter parameter with the given filter expression, such
+// as "Status/Health eq 'Critical'".
+func (q *Query) WithFilter(expr string) *Query {
+	return q.WithParam("$filter", expr)
+}
+
+// WithSelect adds a $select parameter asking the service to return only the
+// given properties, such as "PowerControl", of the resource.
+func (q *Query) WithSelect(properties ...string) *Query {
+	return q.WithParam("$select", strings.Join(properties, ","))
+}
+
+// WithExpandLevels adds an $expand parameter expanding the links of the
+// resource, and of the expanded resources, n levels deep.
+func (q *Query) WithExpandLevels(n int) *Query {
+	return q.WithParam("$expand", ".($levels="+strconv.Itoa(n)+")")
+}
+
+// WithOnly adds the only parameter, which makes a service return the single
+// member of a collection instead of the collection.
+func (q *Query) WithOnly() *Query {
+	q.params = append(q.params, queryParam{key: "only", flag: true})
+	return q
+}
+
+// WithParam adds a parameter, such as an OEM-defined one. The value is
+// percent-encoded.
+func (q *Query) WithParam(key, value string) *Query {
+	q.params = append(q.params, queryParam{key: key, value: value})
+	return q
+}
+
+// Encode returns the query string, without a leading "?". Keys are kept as
+// given since several services do not accept an encoded "$", while values
+// are percent-encoded with spaces as %20.
+func (q *Query) Encode() string {
+	if q == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(q.params))
+	for _, param := range q.params {
+		if param.flag {
+			parts = append(parts, param.key)
+			continue
+		}
+		value := strings.ReplaceAll(url.QueryEscape(param.value), "+", "%20")
+		parts = append(parts, param.key+"="+value)
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// Apply returns uri with the query appended.
+func (q *Query) Apply(uri string) string {
+	query := q.Encode()
+	if query == "" {
+		return uri
+	}
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+
+	return uri + separator + query
+}
@@ -0,0 +1,59 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import "testing"
+
+// TestQueryEncode tests composing query strings.
+func TestQueryEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *Query
+		uri      string
+		expected string
+	}{
+		{
+			name:     "empty",
+			query:    NewQuery(),
+			uri:      "/redfish/v1/Chassis/1/Power",
+			expected: "/redfish/v1/Chassis/1/Power",
+		},
+		{
+			name:     "nil",
+			uri:      "/redfish/v1/Chassis/1/Power",
+			expected: "/redfish/v1/Chassis/1/Power",
+		},
+		{
+			name:     "filter",
+			query:    NewQuery().WithFilter("Status/Health eq 'Critical'"),
+			uri:      "/redfish/v1/Chassis",
+			expected: "/redfish/v1/Chassis?$filter=Status%2FHealth%20eq%20%27Critical%27",
+		},
+		{
+			name:     "select",
+			query:    NewQuery().WithSelect("PowerControl", "Voltages"),
+			uri:      "/redfish/v1/Chassis/1/Power",
+			expected: "/redfish/v1/Chassis/1/Power?$select=PowerControl%2CVoltages",
+		},
+		{
+			name:     "expand and only",
+			query:    NewQuery().WithExpandLevels(2).WithOnly(),
+			uri:      "/redfish/v1/Chassis",
+			expected: "/redfish/v1/Chassis?$expand=.%28%24levels%3D2%29&only",
+		},
+		{
+			name:     "oem param with special characters",
+			query:    NewQuery().WithParam("oem", "a&b=c").WithFilter("Id eq '1'"),
+			uri:      "/redfish/v1/Chassis/1/Power?existing=1",
+			expected: "/redfish/v1/Chassis/1/Power?existing=1&oem=a%26b%3Dc&$filter=Id%20eq%20%271%27",
+		},
+	}
+
+	for _, test := range tests {
+		if uri := test.query.Apply(test.uri); uri != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, uri)
+		}
+	}
+}
@@ -33,10 +33,28 @@ type TestClient struct {
 	// CustomReturnForActions can be used to define custom
 	// return for actions, valid keys are:
 	// http.MethodGet, http.MethodPost, http.MethodPut,
-	// http.MethodPatch and http.MethodDelete.
+	// http.MethodPatch, http.MethodDelete and http.MethodOptions.
 	// For each key it is possible to define a list of
 	// returns (in the order they should be returned).
 	CustomReturnForActions map[string][]interface{}
+	// StrictSchema makes the client report itself as configured for strict
+	// schema handling.
+	StrictSchema bool
+	// UseNumber makes the client report itself as configured to keep the
+	// original text of numbers.
+	UseNumber bool
+}
+
+// IsStrictSchema reports whether the client is configured for strict schema
+// handling.
+func (c *TestClient) IsStrictSchema() bool {
+	return c.StrictSchema
+}
+
+// IsUseNumber reports whether the client is configured to keep the original
+// text of numbers.
+func (c *TestClient) IsUseNumber() bool {
+	return c.UseNumber
 }
 
 // CapturedCalls gets all calls that were made through this instance
@@ -61,7 +79,7 @@ func (c *TestClient) getCustomReturnForAction(action string) interface{} {
 	switch action {
 	case http.MethodGet, http.MethodPost,
 		http.MethodPut, http.MethodPatch,
-		http.MethodDelete:
+		http.MethodDelete, http.MethodOptions:
 		customReturnForAction, ok := c.CustomReturnForActions[action]
 		if !ok ||
 			customReturnForAction == nil ||
@@ -198,3 +216,17 @@ func (c *TestClient) Delete(url string) (*http.Response, error) {
 func (c *TestClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
 	return c.performAction(http.MethodDelete, url, nil, customHeaders)
 }
+
+// RunRawRequestWithHeaders performs a request with any HTTP method against
+// the Redfish service.
+func (c *TestClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	var payload interface{}
+	if payloadBuffer != nil {
+		body, err := io.ReadAll(payloadBuffer)
+		if err != nil {
+			return nil, err
+		}
+		payload = string(body)
+	}
+	return c.performAction(method, url, payload, customHeaders)
+}
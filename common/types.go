@@ -5,11 +5,15 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // DefaultServiceRoot is the default path to the Redfish service endpoint.
@@ -31,6 +35,121 @@ type Client interface {
 	DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error)
 }
 
+// ContextClient is implemented by clients that can issue a GET request under
+// a context supplied by the caller, rather than the one of the client.
+type ContextClient interface {
+	GetWithContext(ctx context.Context, url string) (*http.Response, error)
+}
+
+// GetWithContext performs a GET request of url with c, giving up when ctx is
+// done. Clients that do not implement ContextClient cannot abort a request
+// in flight, so the request is left to finish in the background, its
+// response is discarded and ctx.Err() is returned straight away.
+func GetWithContext(ctx context.Context, c Client, url string) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if contextClient, ok := c.(ContextClient); ok {
+		return contextClient.GetWithContext(ctx, url)
+	}
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := c.Get(url)
+		done <- result{resp, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.resp, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-done; r.resp != nil {
+				r.resp.Body.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// RawClient is implemented by clients that can issue requests with HTTP
+// methods not covered by Client, such as OPTIONS.
+type RawClient interface {
+	RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error)
+}
+
+// Exists reports whether the resource at uri exists, without reading it,
+// such as to check a link is still valid before fetching the resource. Clients
+// implementing RawClient are asked with a HEAD request, falling back to a GET
+// whose body is discarded if the service does not support HEAD for the URI.
+// False is returned if the service answers 404 Not Found, and an error if the
+// request fails otherwise.
+func Exists(c Client, uri string) (bool, error) {
+	if rawClient, ok := c.(RawClient); ok {
+		resp, err := rawClient.RunRawRequestWithHeaders(http.MethodHead, uri, nil, "", nil)
+		switch status := errorStatusCode(err); {
+		case err == nil:
+			resp.Body.Close()
+			return true, nil
+		case status == http.StatusNotFound:
+			return false, nil
+		case status != http.StatusMethodNotAllowed && status != http.StatusNotImplemented:
+			return false, ClassifyError(uri, err)
+		}
+	}
+
+	resp, err := c.Get(uri)
+	if err != nil {
+		if errorStatusCode(err) == http.StatusNotFound {
+			return false, nil
+		}
+		return false, ClassifyError(uri, err)
+	}
+	resp.Body.Close()
+
+	return true, nil
+}
+
+// errorStatusCode returns the HTTP status code of a failed request, or 0 if
+// err does not come from a response of the service.
+func errorStatusCode(err error) int {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.HTTPReturnedStatusCode
+	}
+	return 0
+}
+
+// StrictSchemaClient is implemented by clients that can be configured to
+// reject responses violating the Redfish schema instead of tolerating them.
+type StrictSchemaClient interface {
+	IsStrictSchema() bool
+}
+
+// IsStrictSchema reports whether c is configured to reject responses that
+// violate the Redfish schema.
+func IsStrictSchema(c Client) bool {
+	strict, ok := c.(StrictSchemaClient)
+	return ok && strict.IsStrictSchema()
+}
+
+// UseNumberClient is implemented by clients that can be configured to keep
+// the original text of numbers in responses, as json.Decoder.UseNumber does.
+type UseNumberClient interface {
+	IsUseNumber() bool
+}
+
+// IsUseNumber reports whether c is configured to keep the original text of
+// numbers in responses.
+func IsUseNumber(c Client) bool {
+	useNumber, ok := c.(UseNumberClient)
+	return ok && useNumber.IsUseNumber()
+}
+
 // Entity provides the common basis for all Redfish and Swordfish objects.
 type Entity struct {
 	// ODataID is the location of the resource.
@@ -39,8 +158,9 @@ type Entity struct {
 	ID string `json:"Id,float64"`
 	// Name is the name of the resource or array element.
 	Name string `json:"Name"`
-	// Client is the REST client interface to the system.
-	Client Client
+	// Client is the REST client interface to the system. It is not part of
+	// the JSON form of the entity.
+	Client Client `json:"-"`
 }
 
 
@@ -52,6 +172,12 @@ func (e *Entity) SetClient(c Client) {
 
 // Update commits changes to an entity.
 func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpdates []string) error {
+	return e.UpdateWithHeaders(originalEntity, currentEntity, allowedUpdates, nil)
+}
+
+// UpdateWithHeaders commits changes to an entity like Update, sending the
+// custom headers, such as If-Match, with the PATCH request.
+func (e *Entity) UpdateWithHeaders(originalEntity, currentEntity reflect.Value, allowedUpdates []string, customHeaders map[string]string) error {
 	payload := make(map[string]interface{})
 
 	for i := 0; i < originalEntity.NumField(); i++ {
@@ -102,7 +228,7 @@ func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpda
 	// If there are any allowed updates, try to send updates to the system and
 	// return the result.
 	if len(payload) > 0 {
-		_, err := e.Client.Patch(e.ODataID, payload) // nolint:bodyclose
+		_, err := e.Client.PatchWithHeaders(e.ODataID, payload, customHeaders) // nolint:bodyclose
 		if err != nil {
 			return err
 		}
@@ -111,6 +237,27 @@ func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpda
 	return nil
 }
 
+// PatchSettings sends the settings to the entity as a PATCH of the given
+// properties, without checking them against the writable fields. It can be
+// used for properties that Update does not handle, such as writable Oem
+// properties. If the service rejects the change, a *RedfishError is
+// returned.
+func (e *Entity) PatchSettings(settings map[string]interface{}) error {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	resp, err := e.Client.Patch(e.ODataID, settings)
+	if err != nil {
+		return ClassifyServiceError(e.ODataID, err)
+	}
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+
+	return nil
+}
+
 // Link is an OData link reference
 type Link string
 
@@ -156,6 +303,16 @@ func (l LinksCollection) ToStrings() []string {
 	return l.Members.ToStrings()
 }
 
+// ActionTarget describes an action of a resource, such as a vendor specific
+// action under its Actions/Oem object.
+type ActionTarget struct {
+	// Target is the URI to POST to in order to invoke the action.
+	Target string
+	// ActionInfo is the URI of the ActionInfo resource describing the
+	// parameters of the action, if the service provides one.
+	ActionInfo string `json:"@Redfish.ActionInfo,omitempty"`
+}
+
 // Health indicates the health of a resource.
 type Health string
 
@@ -169,6 +326,42 @@ const (
 	CriticalHealth Health = "Critical"
 )
 
+// severity ranks the health for comparison: OK < Warning < Critical. A
+// health that is not reported ranks below OK, and an unrecognized value ranks
+// as Warning since it cannot be assumed to be normal.
+func (h Health) severity() int {
+	switch h {
+	case "":
+		return 0
+	case OKHealth:
+		return 1
+	case CriticalHealth:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// WorseThan reports whether the health is more severe than other, using the
+// ordering OK < Warning < Critical.
+func (h Health) WorseThan(other Health) bool {
+	return h.severity() > other.severity()
+}
+
+// WorstHealth returns the most severe of the healths, using the ordering
+// OK < Warning < Critical, such as to roll up the health of the members of a
+// resource into a single verdict. Healths that are not reported are ignored
+// unless none are, in which case the empty Health is returned.
+func WorstHealth(healths ...Health) Health {
+	var worst Health
+	for _, health := range healths {
+		if health.WorseThan(worst) {
+			worst = health
+		}
+	}
+	return worst
+}
+
 // DurableNameFormat indicates the type of durable name.
 type DurableNameFormat string
 
@@ -259,11 +452,51 @@ const (
 	UpdatingState State = "Updating"
 )
 
+// Condition describes a condition that requires attention, as reported in
+// the Conditions of a Status.
+type Condition struct {
+	// LogEntry shall contain a link to the log entry created for this
+	// condition, if any.
+	LogEntry Link
+	// Message shall contain a human-readable message describing this
+	// condition.
+	Message string
+	// MessageArgs shall contain the arguments substituted into the message.
+	MessageArgs []string
+	// MessageID shall contain the identifier of the message in a message
+	// registry.
+	MessageID string `json:"MessageId"`
+	// OriginOfCondition shall contain a link to the resource or object that
+	// originated the condition.
+	OriginOfCondition Link
+	// Resolution shall contain the suggested steps to resolve the condition.
+	Resolution string
+	// Severity shall contain the severity of the condition.
+	Severity Health
+	// Timestamp shall indicate the time the condition occurred.
+	Timestamp string
+}
+
 // Status describes the status and health of a resource and its children.
 type Status struct {
-	Health       Health `json:"Health"`
-	HealthRollup Health `json:"HealthRollup"`
-	State        State  `json:"State"`
+	// Conditions shall contain the conditions requiring attention in this
+	// resource or its children.
+	Conditions   []Condition `json:"Conditions,omitempty"`
+	Health       Health      `json:"Health"`
+	HealthRollup Health      `json:"HealthRollup"`
+	State        State       `json:"State"`
+}
+
+// IsOperational reports whether the resource is enabled and doing its job,
+// that is its State is Enabled or Quiesced and its Health is not Critical.
+// A resource that does not report its State is assumed to be enabled.
+func (s Status) IsOperational() bool {
+	switch s.State {
+	case "", EnabledState, QuiescedState:
+		return s.Health != CriticalHealth
+	default:
+		return false
+	}
 }
 
 // LocationType shall name the type of location in use.
@@ -769,3 +1002,172 @@ type ErrExtendedInfo struct {
 	// An optional string describing recommended action(s) to take to resolve the error.
 	Resolution string
 }
+
+// ResourceTypeError is returned when a service responds with a resource of a
+// different type than the one requested, such as a Chassis body being
+// returned where a Power resource was expected.
+type ResourceTypeError struct {
+	// Expected contains the resource types that would have been accepted.
+	Expected []string
+	// ODataType is the @odata.type reported in the response.
+	ODataType string
+	// ODataContext is the @odata.context reported in the response.
+	ODataContext string
+}
+
+func (e *ResourceTypeError) Error() string {
+	received := e.ODataType
+	if received == "" {
+		received = e.ODataContext
+	}
+	return fmt.Sprintf("expected %s resource but received %q",
+		strings.Join(e.Expected, " or "), received)
+}
+
+// CheckResourceType verifies that the @odata.type, or the @odata.context if
+// no type was provided, identifies one of the expected resource types. A
+// *ResourceTypeError is returned on mismatch. If neither annotation is present
+// the type cannot be determined and no error is returned.
+func CheckResourceType(odataType, odataContext string, expected ...string) error {
+	var name string
+	switch {
+	case odataType != "":
+		name = strings.SplitN(strings.TrimPrefix(odataType, "#"), ".", 2)[0]
+	case odataContext != "":
+		name = contextResourceName(odataContext)
+	default:
+		return nil
+	}
+
+	for _, e := range expected {
+		if name == e {
+			return nil
+		}
+	}
+
+	return &ResourceTypeError{
+		Expected:     expected,
+		ODataType:    odataType,
+		ODataContext: odataContext,
+	}
+}
+
+// ParseODataType extracts the resource type name and schema version from an
+// @odata.type value, such as "Power", 1, 7 and 1 from "#Power.v1_7_1.Power",
+// so code can depend on the version of the schema a service implements. The
+// older "#Chassis.1.0.0.Chassis" form is also understood. Unversioned types,
+// such as "#PowerCollection.PowerCollection", have a zero version. An error
+// is returned if the value is missing or malformed.
+func ParseODataType(odataType string) (name string, major, minor, errata int, err error) {
+	parts := strings.Split(strings.TrimPrefix(odataType, "#"), ".")
+	if parts[0] == "" || len(parts) < 2 {
+		return "", 0, 0, 0, fmt.Errorf("malformed @odata.type %q", odataType)
+	}
+	name = parts[0]
+
+	var version []string
+	switch {
+	case len(parts) == 2:
+		return name, 0, 0, 0, nil
+	case len(parts) == 3 && strings.HasPrefix(parts[1], "v"):
+		version = strings.Split(parts[1][1:], "_")
+	case len(parts) == 5:
+		version = parts[1:4]
+	}
+	if len(version) < 2 || len(version) > 3 {
+		return "", 0, 0, 0, fmt.Errorf("malformed version in @odata.type %q", odataType)
+	}
+
+	numbers := make([]int, 3)
+	for i, v := range version {
+		numbers[i], err = strconv.Atoi(v)
+		if err != nil || numbers[i] < 0 {
+			return "", 0, 0, 0, fmt.Errorf("malformed version in @odata.type %q", odataType)
+		}
+	}
+
+	return name, numbers[0], numbers[1], numbers[2], nil
+}
+
+// contextResourceName extracts the resource type name from an @odata.context
+// value. Both the "$metadata#Power.Power" form and the older path based
+// "$metadata#Chassis/Members(*)/Self/Power/$entity" form are handled.
+func contextResourceName(odataContext string) string {
+	fragment := odataContext
+	if i := strings.LastIndex(fragment, "#"); i >= 0 {
+		fragment = fragment[i+1:]
+	}
+
+	if strings.Contains(fragment, "/") {
+		segments := strings.Split(strings.TrimSuffix(fragment, "/$entity"), "/")
+		return segments[len(segments)-1]
+	}
+
+	return strings.SplitN(fragment, ".", 2)[0]
+}
+
+// DetachedClient is the Client of resources decoded from data already read,
+// such as by redfish.ParsePower, rather than fetched from a service. Every
+// request fails with ErrNoClient, so methods that follow links or send
+// updates report that there is no service instead of panicking.
+type DetachedClient struct{}
+
+// Get fails with ErrNoClient.
+func (DetachedClient) Get(url string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// GetWithHeaders fails with ErrNoClient.
+func (DetachedClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// Post fails with ErrNoClient.
+func (DetachedClient) Post(url string, payload interface{}) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// PostWithHeaders fails with ErrNoClient.
+func (DetachedClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// PostMultipart fails with ErrNoClient.
+func (DetachedClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// PostMultipartWithHeaders fails with ErrNoClient.
+func (DetachedClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// Patch fails with ErrNoClient.
+func (DetachedClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// PatchWithHeaders fails with ErrNoClient.
+func (DetachedClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// Put fails with ErrNoClient.
+func (DetachedClient) Put(url string, payload interface{}) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// PutWithHeaders fails with ErrNoClient.
+func (DetachedClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// Delete fails with ErrNoClient.
+func (DetachedClient) Delete(url string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
+
+// DeleteWithHeaders fails with ErrNoClient.
+func (DetachedClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return nil, ErrNoClient
+}
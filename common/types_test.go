@@ -0,0 +1,200 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestEntityPatchSettings tests patching arbitrary properties of an entity.
+func TestEntityPatchSettings(t *testing.T) {
+	testClient := &TestClient{}
+	entity := Entity{ODataID: "/redfish/v1/Chassis/1/Power"}
+	entity.SetClient(testClient)
+
+	err := entity.PatchSettings(nil)
+	if err != nil {
+		t.Errorf("Error patching no settings: %s", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Expected no call for empty settings, captured: %v", testClient.CapturedCalls())
+	}
+
+	err = entity.PatchSettings(map[string]interface{}{
+		"IndicatorLED": "Lit",
+		"Oem":          map[string]interface{}{"Vendor": map[string]interface{}{"Mode": "Eco"}},
+	})
+	if err != nil {
+		t.Errorf("Error patching settings: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != http.MethodPatch ||
+		calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Fatalf("Expected a PATCH of the entity, captured: %v", calls)
+	}
+
+	if calls[0].Payload != "map[IndicatorLED:Lit Oem:map[Vendor:map[Mode:Eco]]]" {
+		t.Errorf("Unexpected payload: %s", calls[0].Payload)
+	}
+}
+
+// TestEntityPatchSettingsRejected tests a rejected patch is a RedfishError.
+func TestEntityPatchSettingsRejected(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {
+				&http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(propertyNotWritableBody)),
+				},
+			},
+		},
+	}
+	entity := Entity{ODataID: "/redfish/v1/Chassis/1/Power"}
+	entity.SetClient(testClient)
+
+	err := entity.PatchSettings(map[string]interface{}{"IndicatorLED": "Lit"})
+	var redfishErr *RedfishError
+	if !errors.As(err, &redfishErr) || !redfishErr.HasMessageID("Base.1.8.PropertyNotWritable") {
+		t.Errorf("Expected PropertyNotWritable service error, got: %v", err)
+	}
+
+	// Failures that do not come from the service are left unclassified
+	entity.SetClient(DetachedClient{})
+	err = entity.PatchSettings(map[string]interface{}{"IndicatorLED": "Lit"})
+	var transportErr *TransportError
+	if !errors.Is(err, ErrNoClient) || errors.As(err, &transportErr) {
+		t.Errorf("Expected ErrNoClient, got: %v", err)
+	}
+}
+
+// TestWorstHealth tests ordering and rolling up healths.
+func TestWorstHealth(t *testing.T) {
+	tests := []struct {
+		healths []Health
+		worst   Health
+	}{
+		{nil, ""},
+		{[]Health{"", ""}, ""},
+		{[]Health{OKHealth, ""}, OKHealth},
+		{[]Health{OKHealth, WarningHealth, OKHealth}, WarningHealth},
+		{[]Health{CriticalHealth, WarningHealth, OKHealth}, CriticalHealth},
+		{[]Health{OKHealth, "Degraded"}, "Degraded"},
+		{[]Health{"Degraded", CriticalHealth}, CriticalHealth},
+	}
+
+	for _, test := range tests {
+		if worst := WorstHealth(test.healths...); worst != test.worst {
+			t.Errorf("WorstHealth(%v): expected %q, got %q", test.healths, test.worst, worst)
+		}
+	}
+
+	if !CriticalHealth.WorseThan(WarningHealth) || !WarningHealth.WorseThan(OKHealth) || OKHealth.WorseThan(OKHealth) {
+		t.Errorf("Invalid health ordering")
+	}
+}
+
+// TestStatusIsOperational tests judging whether a resource is operational.
+func TestStatusIsOperational(t *testing.T) {
+	tests := []struct {
+		status      Status
+		operational bool
+	}{
+		{Status{State: EnabledState, Health: OKHealth}, true},
+		{Status{State: EnabledState, Health: WarningHealth}, true},
+		{Status{State: EnabledState, Health: CriticalHealth}, false},
+		{Status{State: QuiescedState}, true},
+		{Status{Health: OKHealth}, true},
+		{Status{State: AbsentState}, false},
+		{Status{State: StandbySpareState, Health: OKHealth}, false},
+		{Status{State: DisabledState, Health: OKHealth}, false},
+	}
+
+	for _, test := range tests {
+		if test.status.IsOperational() != test.operational {
+			t.Errorf("%+v: expected IsOperational %t", test.status, test.operational)
+		}
+	}
+}
+
+// TestExists tests checking whether resources exist.
+func TestExists(t *testing.T) {
+	c := NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", `{"Id": "Power"}`)
+	c.SetError(http.MethodHead, "/redfish/v1/Chassis/2/Power", http.StatusMethodNotAllowed, "")
+	c.SetBody("/redfish/v1/Chassis/2/Power", `{"Id": "Power"}`)
+	c.SetError(http.MethodHead, "/redfish/v1/Chassis/3/Power", http.StatusInternalServerError, "")
+
+	tests := []struct {
+		uri     string
+		exists  bool
+		fails   bool
+		methods []string
+	}{
+		{"/redfish/v1/Chassis/1/Power", true, false, []string{http.MethodHead}},
+		{"/redfish/v1/Chassis/9/Power", false, false, []string{http.MethodHead}},
+		{"/redfish/v1/Chassis/2/Power", true, false, []string{http.MethodHead, http.MethodGet}},
+		{"/redfish/v1/Chassis/3/Power", false, true, []string{http.MethodHead}},
+	}
+
+	for _, test := range tests {
+		before := len(c.Calls())
+		exists, err := Exists(c, test.uri)
+		if exists != test.exists || (err != nil) != test.fails {
+			t.Errorf("%s: expected %t (fails %t), got %t: %v", test.uri, test.exists, test.fails, exists, err)
+		}
+
+		var methods []string
+		for _, call := range c.Calls()[before:] {
+			methods = append(methods, call.Method)
+		}
+		if strings.Join(methods, ",") != strings.Join(test.methods, ",") {
+			t.Errorf("%s: expected requests %v, got %v", test.uri, test.methods, methods)
+		}
+	}
+
+	var redfishErr *RedfishError
+	if _, err := Exists(c, "/redfish/v1/Chassis/3/Power"); !errors.As(err, &redfishErr) || redfishErr.StatusCode() != http.StatusInternalServerError {
+		t.Errorf("Expected a classified error, got: %v", err)
+	}
+}
+
+// TestParseODataType tests extracting schema versions from @odata.type.
+func TestParseODataType(t *testing.T) {
+	tests := []struct {
+		odataType            string
+		name                 string
+		major, minor, errata int
+		fails                bool
+	}{
+		{"#Power.v1_7_1.Power", "Power", 1, 7, 1, false},
+		{"Power.v1_7_1.Power", "Power", 1, 7, 1, false},
+		{"#PowerSubsystem.v1_1.PowerSubsystem", "PowerSubsystem", 1, 1, 0, false},
+		{"#Chassis.1.0.0.Chassis", "Chassis", 1, 0, 0, false},
+		{"#PowerCollection.PowerCollection", "PowerCollection", 0, 0, 0, false},
+		{"", "", 0, 0, 0, true},
+		{"#Power", "", 0, 0, 0, true},
+		{"#Power.vX_7_1.Power", "", 0, 0, 0, true},
+		{"#Power.v1.Power", "", 0, 0, 0, true},
+		{"#Power.v1_7_1_2.Power", "", 0, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		name, major, minor, errata, err := ParseODataType(test.odataType)
+		if (err != nil) != test.fails {
+			t.Errorf("%q: expected failure %t, got: %v", test.odataType, test.fails, err)
+		}
+		if name != test.name || major != test.major || minor != test.minor || errata != test.errata {
+			t.Errorf("%q: expected %s %d.%d.%d, got %s %d.%d.%d", test.odataType,
+				test.name, test.major, test.minor, test.errata, name, major, minor, errata)
+		}
+	}
+}
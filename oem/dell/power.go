@@ -0,0 +1,38 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package dell provides helpers for reading the Dell iDRAC specific
+// properties of Redfish resources.
+package dell
+
+import (
+	"github.com/ciferlu1024/gofish/redfish"
+)
+
+// powerOem is the Dell section of the Power resource OEM properties.
+type powerOem struct {
+	Dell struct {
+		InstantaneousHeadroom *float64
+		PeakHeadroom          *float64
+	}
+}
+
+// DellPowerHeadroom returns the instantaneous and peak power headroom, in
+// watts, that iDRAC reports in the OEM section of a Power resource. False is
+// returned if p has no Dell OEM headroom properties.
+func DellPowerHeadroom(p *redfish.Power) (instant, peak float64, ok bool) { // nolint:golint
+	if p == nil {
+		return 0, 0, false
+	}
+
+	var oem powerOem
+	if err := p.GetOem(&oem); err != nil {
+		return 0, 0, false
+	}
+	if oem.Dell.InstantaneousHeadroom == nil || oem.Dell.PeakHeadroom == nil {
+		return 0, 0, false
+	}
+
+	return *oem.Dell.InstantaneousHeadroom, *oem.Dell.PeakHeadroom, true
+}
@@ -0,0 +1,56 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package dell
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/redfish"
+)
+
+// TestDellPowerHeadroom tests reading the headroom from the Dell OEM section.
+func TestDellPowerHeadroom(t *testing.T) {
+	var result redfish.Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/System.Embedded.1/Power",
+		"Id": "Power",
+		"Oem": {
+			"Dell": {
+				"InstantaneousHeadroom": 512.5,
+				"PeakHeadroom": 300
+			}
+		}
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	instant, peak, ok := DellPowerHeadroom(&result)
+	if !ok {
+		t.Fatal("Expected headroom to be found")
+	}
+	if instant != 512.5 || peak != 300 {
+		t.Errorf("Invalid headroom: %g, %g", instant, peak)
+	}
+}
+
+// TestDellPowerHeadroomMissing tests resources without Dell headroom.
+func TestDellPowerHeadroomMissing(t *testing.T) {
+	for _, body := range []string{
+		`{"Id": "Power"}`,
+		`{"Id": "Power", "Oem": {"Contoso": {"PeakHeadroom": 1}}}`,
+		`{"Id": "Power", "Oem": {"Dell": {"InstantaneousHeadroom": 1}}}`,
+	} {
+		var result redfish.Power
+		if err := json.Unmarshal([]byte(body), &result); err != nil {
+			t.Fatalf("Error decoding JSON: %s", err)
+		}
+		if _, _, ok := DellPowerHeadroom(&result); ok {
+			t.Errorf("Expected no headroom for %s", body)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// ChargeState is the charge state of a battery.
+type ChargeState string
+
+const (
+
+	// IdleChargeState The battery is idle.
+	IdleChargeState ChargeState = "Idle"
+	// ChargingChargeState The battery is charging.
+	ChargingChargeState ChargeState = "Charging"
+	// DischargingChargeState The battery is discharging.
+	DischargingChargeState ChargeState = "Discharging"
+)
+
+// Battery shall represent a battery for a Redfish implementation, such as a
+// member of the Batteries collection of a PowerSubsystem. It may be a UPS or
+// a backup battery for a storage controller.
+type Battery struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CapacityActualAmpHours shall contain the actual maximum capacity of
+	// this battery in amp-hours.
+	CapacityActualAmpHours float64
+	// CapacityActualWattHours shall contain the actual maximum capacity of
+	// this battery in watt-hours.
+	CapacityActualWattHours float64
+	// CapacityRatedAmpHours shall contain the rated maximum capacity of
+	// this battery in amp-hours.
+	CapacityRatedAmpHours float64
+	// CapacityRatedWattHours shall contain the rated maximum capacity of
+	// this battery in watt-hours.
+	CapacityRatedWattHours float64
+	// ChargeState shall contain the charge state of this battery.
+	ChargeState ChargeState
+	// Description provides a description of this resource.
+	Description string
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this battery.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain the location information of this battery.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the battery.
+	Manufacturer string
+	// MaxChargeRateAmps shall contain the maximum charge rate of this
+	// battery in amps.
+	MaxChargeRateAmps float64
+	// MaxChargeVoltage shall contain the maximum voltage that can be applied
+	// to this battery.
+	MaxChargeVoltage float64
+	// MaxDischargeRateAmps shall contain the maximum discharge rate of this
+	// battery in amps.
+	MaxDischargeRateAmps float64
+	// Model shall contain the model information as defined by the
+	// manufacturer for this battery.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this battery.
+	PartNumber string
+	// ProductionDate shall contain the date of production or manufacture
+	// for this battery.
+	ProductionDate string
+	// Replaceable shall indicate whether this component can be independently
+	// replaced as allowed by the vendor's replacement policy.
+	Replaceable bool
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this battery.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this battery.
+	SparePartNumber string
+	// StateOfHealthPercent shall contain the state of health, in percent
+	// units, of this battery.
+	StateOfHealthPercent SensorExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Version shall contain the hardware version of this battery as
+	// determined by the vendor or supplier.
+	Version string
+	// assembly is the link to the assembly of the battery.
+	assembly string
+	// metrics is the link to the metrics of the battery.
+	metrics string
+}
+
+// UnmarshalJSON unmarshals a Battery object from the raw JSON.
+func (battery *Battery) UnmarshalJSON(b []byte) error {
+	type temp Battery
+	var t struct {
+		temp
+		Assembly common.Link
+		Metrics  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*battery = Battery(t.temp)
+
+	// Extract the links to other entities for later
+	battery.assembly = string(t.Assembly)
+	battery.metrics = string(t.Metrics)
+
+	return nil
+}
+
+// GetBattery will get a Battery instance from the service.
+func GetBattery(c common.Client, uri string) (*Battery, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var battery Battery
+	err = json.NewDecoder(resp.Body).Decode(&battery)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	battery.SetClient(c)
+	return &battery, nil
+}
+
+// ListReferencedBatteries gets the collection of Battery from
+// a provided reference.
+func ListReferencedBatteries(c common.Client, link string) ([]*Battery, error) { //nolint:dupl
+	var result []*Battery
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, batteryLink := range links.ItemLinks {
+		battery, err := GetBattery(c, batteryLink)
+		if err != nil {
+			collectionError.Failures[batteryLink] = err
+		} else {
+			result = append(result, battery)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Assembly gets the Assembly for this battery.
+func (battery *Battery) Assembly() (*Assembly, error) {
+	if battery.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(battery.Client, battery.assembly)
+}
+
+// Metrics gets the charge and discharge readings of this battery, or nil if
+// the service does not report them.
+func (battery *Battery) Metrics() (*BatteryMetrics, error) {
+	if battery.metrics == "" {
+		return nil, nil
+	}
+
+	return GetBatteryMetrics(battery.Client, battery.metrics)
+}
+
+// BatteryMetrics shall contain the usage and health statistics of a battery.
+type BatteryMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CellVoltages shall contain the cell voltages, in volt units, for this
+	// battery.
+	CellVoltages []SensorExcerpt
+	// ChargePercent shall contain the amount of charge available, in percent
+	// units, in this battery.
+	ChargePercent SensorExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// DischargeCycles shall contain the number of discharges this battery
+	// sustained.
+	DischargeCycles float64
+	// InputCurrentAmps shall contain the input current, in ampere units, for
+	// this battery.
+	InputCurrentAmps SensorExcerpt
+	// InputVoltage shall contain the input voltage, in volt units, for this
+	// battery.
+	InputVoltage SensorExcerpt
+	// OutputCurrentAmps shall contain the output currents, in ampere units,
+	// for this battery.
+	OutputCurrentAmps []SensorExcerpt
+	// OutputVoltages shall contain the output voltages, in volt units, for
+	// this battery.
+	OutputVoltages []SensorExcerpt
+	// StoredChargeAmpHours shall contain the stored charge, in amp-hour
+	// units, for this battery.
+	StoredChargeAmpHours SensorExcerpt
+	// StoredEnergyWattHours shall contain the stored energy, in watt-hour
+	// units, for this battery.
+	StoredEnergyWattHours SensorExcerpt
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this battery.
+	TemperatureCelsius SensorExcerpt
+}
+
+// GetBatteryMetrics will get a BatteryMetrics instance from the service.
+func GetBatteryMetrics(c common.Client, uri string) (*BatteryMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var metrics BatteryMetrics
+	err = json.NewDecoder(resp.Body).Decode(&metrics)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	metrics.SetClient(c)
+	return &metrics, nil
+}
@@ -0,0 +1,125 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"testing"
+)
+
+var batteryBody = `{
+	"@odata.type": "#Battery.v1_0_0.Battery",
+	"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1",
+	"Id": "Module1",
+	"Name": "Battery 1",
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"Location": {"PartLocation": {"ServiceLabel": "Battery 1", "LocationType": "Bay", "LocationOrdinalValue": 0}},
+	"Model": "RKS-610",
+	"Manufacturer": "Contoso Power",
+	"SerialNumber": "3F5A8C54207B7E3",
+	"PartNumber": "310-8A5B",
+	"HotPluggable": true,
+	"ProductionDate": "2021-01-08T00:00:00Z",
+	"CapacityRatedWattHours": 12,
+	"CapacityActualWattHours": 11.5,
+	"MaxDischargeRateAmps": 1,
+	"ChargeState": "Charging",
+	"StateOfHealthPercent": {
+		"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/Battery1Health",
+		"Reading": 91
+	},
+	"Assembly": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Assembly"},
+	"Metrics": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics"}
+}`
+
+var batteryMetricsBody = `{
+	"@odata.type": "#BatteryMetrics.v1_0_0.BatteryMetrics",
+	"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics",
+	"Id": "Metrics",
+	"Name": "Metrics for Battery 1",
+	"ChargePercent": {
+		"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/Battery1Charge",
+		"Reading": 66.2
+	},
+	"InputVoltage": {"Reading": 12.1},
+	"OutputCurrentAmps": [
+		{"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/Battery1Current", "Reading": 0.1}
+	],
+	"DischargeCycles": 165
+}`
+
+// TestGetBattery tests getting a Battery and its metrics.
+func TestGetBattery(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1":         batteryBody,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics": batteryMetricsBody,
+		"/redfish/v1/Chassis/1U/Sensors/Battery1Health": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/Sensors/Battery1Health",
+			"Id": "Battery1Health",
+			"Reading": 91,
+			"ReadingUnits": "%"
+		}`,
+	}}
+
+	result, err := GetBattery(testClient, "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1")
+	if err != nil {
+		t.Fatalf("Error getting battery: %s", err)
+	}
+
+	if result.ID != "Module1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ChargeState != ChargingChargeState {
+		t.Errorf("Invalid ChargeState: %s", result.ChargeState)
+	}
+
+	if result.CapacityRatedWattHours != 12 || result.CapacityActualWattHours != 11.5 {
+		t.Errorf("Invalid capacity: %g, %g", result.CapacityRatedWattHours, result.CapacityActualWattHours)
+	}
+
+	if result.StateOfHealthPercent.Reading != 91 {
+		t.Errorf("Invalid StateOfHealthPercent: %g", result.StateOfHealthPercent.Reading)
+	}
+
+	if result.Location.PartLocation.ServiceLabel != "Battery 1" {
+		t.Errorf("Invalid Location: %+v", result.Location)
+	}
+
+	if result.assembly != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Assembly" {
+		t.Errorf("Invalid assembly link: %s", result.assembly)
+	}
+
+	sensor, err := result.StateOfHealthPercent.Sensor(result.Client)
+	if err != nil {
+		t.Fatalf("Error getting state of health sensor: %s", err)
+	}
+	if sensor.ID != "Battery1Health" || sensor.ReadingUnits != "%" {
+		t.Errorf("Invalid state of health sensor: %s", sensor.ID)
+	}
+
+	metrics, err := result.Metrics()
+	if err != nil {
+		t.Fatalf("Error getting battery metrics: %s", err)
+	}
+
+	if metrics.ChargePercent.Reading != 66.2 ||
+		metrics.ChargePercent.DataSourceURI != "/redfish/v1/Chassis/1U/Sensors/Battery1Charge" {
+		t.Errorf("Invalid ChargePercent: %+v", metrics.ChargePercent)
+	}
+
+	if metrics.InputVoltage.Reading != 12.1 || metrics.DischargeCycles != 165 {
+		t.Errorf("Invalid metrics: %+v", metrics)
+	}
+
+	if len(metrics.OutputCurrentAmps) != 1 || metrics.OutputCurrentAmps[0].Reading != 0.1 {
+		t.Errorf("Invalid OutputCurrentAmps: %+v", metrics.OutputCurrentAmps)
+	}
+
+	// An excerpt without a data source has no sensor to fetch
+	sensor, err = metrics.InputVoltage.Sensor(result.Client)
+	if sensor != nil || err != nil {
+		t.Errorf("Expected no sensor for an excerpt without data source: %v, %v", sensor, err)
+	}
+}
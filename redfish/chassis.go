@@ -8,11 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"io/ioutil"
-	"bytes"
-	"os"
-	"io"
-	"strings"
 
 	"github.com/ciferlu1024/gofish/common"
 )
@@ -230,6 +225,8 @@ type Chassis struct {
 	WidthMm         float64
 	thermal         string
 	power           string
+	powerSubsystem  string
+	sensors         string
 	networkAdapters string
 	computerSystems []string
 	resourceBlocks  []string
@@ -264,6 +261,8 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 		Drives          common.Link
 		Thermal         common.Link
 		Power           common.Link
+		PowerSubsystem  common.Link
+		Sensors         common.Link
 		NetworkAdapters common.Link
 		Links           linkReference
 		Actions         Actions
@@ -284,6 +283,8 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	}
 	chassis.thermal = string(t.Thermal)
 	chassis.power = string(t.Power)
+	chassis.powerSubsystem = string(t.PowerSubsystem)
+	chassis.sensors = string(t.Sensors)
 	chassis.networkAdapters = string(t.NetworkAdapters)
 	chassis.computerSystems = t.Links.ComputerSystems.ToStrings()
 	chassis.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
@@ -323,67 +324,11 @@ func GetChassis(c common.Client, uri string) (*Chassis, error) {
 	resp, err := c.Get(uri)
 	if err != nil {
 		return nil, err
-	}else{
 	}
 	defer resp.Body.Close()
 
-	// os.Stdout 输出原始json内容!
-        mybodys, _ := ioutil.ReadAll(resp.Body)
-	var out bytes.Buffer
-	err = json.Indent(&out, mybodys, "", "\t")
-	if err != nil {
-		fmt.Println("**************************chassis json body 报错!", err)
-	}
-	//out.WriteTo(os.Stdout)
-
-	file, _ := os.Create("/tmp/chassisjson.txt")
-	defer file.Close()
-	out.WriteTo(file)
-
-	// 读取json文件获取json数据
-	jsonFile, err := os.Open("/tmp/chassisjson.txt")
-	if err != nil {
-		fmt.Println("error opening json file")
-	}
-
-	defer jsonFile.Close()
-	jsonData, err := ioutil.ReadAll(jsonFile)
-	if err!= nil {
-		fmt.Println("error reading json file")
-	}
-
-	// 重新解析json数据
-
-	var r interface{}
-	err = json.Unmarshal(jsonData, &r)
-	// fmt.Println("r的值：", r)
-
-	// 修改json数据部分字段的格式
-	newbodymap, _ := r.(map[string]interface{})
-
-	//fmt.Printf("newbodymap id的值:%v , 类型:%T \n", newbodymap["Id"], newbodymap["Id"])
-	//fmt.Printf("newbodymap power的值:%v , 类型:%T \n", newbodymap["Power"], newbodymap["Power"])
-	newbodymap["Id"] = "1"
-	delete(newbodymap["Power"].(map[string]interface{}), "Health")
-	//fmt.Printf("newbodymap id的值:%v , 类型:%T \n", newbodymap["Id"], newbodymap["Id"])
-	//fmt.Printf("newbodymap power的值:%v , 类型:%T \n", newbodymap["Power"], newbodymap["Power"])
-	newbodyjson, err := json.Marshal(newbodymap)
-	if err != nil {
-		fmt.Println("*************newbodyjson err:", err)
-	}
-
-	/*
-	var out1 bytes.Buffer
-        err = json.Indent(&out1, newbodyjson, "", "\t")
-        out1.WriteTo(os.Stdout)
-	*/
-
 	var chassis Chassis
-	var newjsonreader io.Reader
-	newjsonreader = strings.NewReader(string(newbodyjson))
-	//err = json.NewDecoder(resp.Body).Decode(&chassis)
-	err = json.NewDecoder(newjsonreader).Decode(&chassis)
-
+	err = json.NewDecoder(resp.Body).Decode(&chassis)
 	if err != nil {
 		return nil, err
 	}
@@ -485,6 +430,21 @@ func (chassis *Chassis) Power() (*Power, error) {
 	return power, nil
 }
 
+// PowerSubsystem gets the power subsystem of the chassis, which newer
+// services provide in place of Power.
+func (chassis *Chassis) PowerSubsystem() (*PowerSubsystem, error) {
+	if chassis.powerSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetPowerSubsystem(chassis.Client, chassis.powerSubsystem)
+}
+
+// Sensors gets the sensors of the chassis.
+func (chassis *Chassis) Sensors() ([]*Sensor, error) {
+	return ListReferencedSensors(chassis.Client, chassis.sensors)
+}
+
 // ComputerSystems returns the collection of systems from this chassis
 func (chassis *Chassis) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
@@ -45,6 +45,12 @@ var chassisBody = `{
 		"Power": {
 			"@odata.id": "/redfish/v1/Chassis/Chassis-1/Power"
 		},
+		"PowerSubsystem": {
+			"@odata.id": "/redfish/v1/Chassis/Chassis-1/PowerSubsystem"
+		},
+		"Sensors": {
+			"@odata.id": "/redfish/v1/Chassis/Chassis-1/Sensors"
+		},
 		"Links": {
 			"ComputerSystems": [
 				{
@@ -160,6 +166,14 @@ func TestChassis(t *testing.T) {
 		t.Errorf("Received invalid power reference: %s", result.power)
 	}
 
+	if result.powerSubsystem != "/redfish/v1/Chassis/Chassis-1/PowerSubsystem" {
+		t.Errorf("Received invalid power subsystem reference: %s", result.powerSubsystem)
+	}
+
+	if result.sensors != "/redfish/v1/Chassis/Chassis-1/Sensors" {
+		t.Errorf("Received invalid sensors reference: %s", result.sensors)
+	}
+
 	if len(result.computerSystems) != 1 {
 		t.Errorf("Expected 1 computer system, got %d", len(result.computerSystems))
 	}
@@ -0,0 +1,339 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// CircuitType is the type of a circuit of a power distribution unit.
+type CircuitType string
+
+const (
+
+	// MainsCircuitType A mains input or utility circuit.
+	MainsCircuitType CircuitType = "Mains"
+	// BranchCircuitType A branch (output) circuit.
+	BranchCircuitType CircuitType = "Branch"
+	// SubfeedCircuitType A subfeed (output) circuit.
+	SubfeedCircuitType CircuitType = "Subfeed"
+	// FeederCircuitType A feeder (output) circuit.
+	FeederCircuitType CircuitType = "Feeder"
+	// BusCircuitType An electrical bus circuit.
+	BusCircuitType CircuitType = "Bus"
+)
+
+// PhaseWiringType is the number of wires and phases of a circuit.
+type PhaseWiringType string
+
+const (
+
+	// OneOrTwoPhase3WirePhaseWiringType Single or Two-Phase / 3-Wire (Line1,
+	// Line2 or Neutral, Protective Earth).
+	OneOrTwoPhase3WirePhaseWiringType PhaseWiringType = "OneOrTwoPhase3Wire"
+	// OnePhase3WirePhaseWiringType Single-phase / 3-Wire (Line1, Neutral,
+	// Protective Earth).
+	OnePhase3WirePhaseWiringType PhaseWiringType = "OnePhase3Wire"
+	// TwoPhase3WirePhaseWiringType Two-phase / 3-Wire (Line1, Line2,
+	// Protective Earth).
+	TwoPhase3WirePhaseWiringType PhaseWiringType = "TwoPhase3Wire"
+	// TwoPhase4WirePhaseWiringType Two-phase / 4-Wire (Line1, Line2,
+	// Neutral, Protective Earth).
+	TwoPhase4WirePhaseWiringType PhaseWiringType = "TwoPhase4Wire"
+	// ThreePhase4WirePhaseWiringType Three-phase / 4-Wire (Line1, Line2,
+	// Line3, Protective Earth).
+	ThreePhase4WirePhaseWiringType PhaseWiringType = "ThreePhase4Wire"
+	// ThreePhase5WirePhaseWiringType Three-phase / 5-Wire (Line1, Line2,
+	// Line3, Neutral, Protective Earth).
+	ThreePhase5WirePhaseWiringType PhaseWiringType = "ThreePhase5Wire"
+)
+
+// NominalVoltageType is the nominal voltage of a circuit.
+type NominalVoltageType string
+
+const (
+
+	// AC100To127VNominalVoltageType AC 100-127V nominal.
+	AC100To127VNominalVoltageType NominalVoltageType = "AC100To127V"
+	// AC100To240VNominalVoltageType AC 100-240V nominal.
+	AC100To240VNominalVoltageType NominalVoltageType = "AC100To240V"
+	// AC120VNominalVoltageType AC 120V nominal.
+	AC120VNominalVoltageType NominalVoltageType = "AC120V"
+	// AC200To240VNominalVoltageType AC 200-240V nominal.
+	AC200To240VNominalVoltageType NominalVoltageType = "AC200To240V"
+	// AC208VNominalVoltageType AC 208V nominal.
+	AC208VNominalVoltageType NominalVoltageType = "AC208V"
+	// AC230VNominalVoltageType AC 230V nominal.
+	AC230VNominalVoltageType NominalVoltageType = "AC230V"
+	// AC240VNominalVoltageType AC 240V nominal.
+	AC240VNominalVoltageType NominalVoltageType = "AC240V"
+	// AC277VNominalVoltageType AC 277V nominal.
+	AC277VNominalVoltageType NominalVoltageType = "AC277V"
+	// AC400VNominalVoltageType AC 400V or 415V nominal.
+	AC400VNominalVoltageType NominalVoltageType = "AC400V"
+	// AC480VNominalVoltageType AC 480V nominal.
+	AC480VNominalVoltageType NominalVoltageType = "AC480V"
+	// DC48VNominalVoltageType DC 48V nominal.
+	DC48VNominalVoltageType NominalVoltageType = "DC48V"
+	// DC240VNominalVoltageType DC 240V nominal.
+	DC240VNominalVoltageType NominalVoltageType = "DC240V"
+	// DC380VNominalVoltageType High Voltage DC (380V).
+	DC380VNominalVoltageType NominalVoltageType = "DC380V"
+	// DCNeg48VNominalVoltageType -48V DC.
+	DCNeg48VNominalVoltageType NominalVoltageType = "DCNeg48V"
+)
+
+// CurrentSensors shall contain the current readings of each line of a
+// polyphase circuit. Lines the circuit does not have are nil.
+type CurrentSensors struct {
+	// Line1 shall contain the line current, in amperes, for L1.
+	Line1 *SensorExcerpt
+	// Line2 shall contain the line current, in amperes, for L2.
+	Line2 *SensorExcerpt
+	// Line3 shall contain the line current, in amperes, for L3.
+	Line3 *SensorExcerpt
+	// Neutral shall contain the line current, in amperes, for the Neutral
+	// line.
+	Neutral *SensorExcerpt
+}
+
+// Lines returns the current readings of the reported lines, in order from
+// Line1 to Line3, excluding Neutral.
+func (sensors *CurrentSensors) Lines() []SensorExcerpt {
+	return reportedExcerpts(sensors.Line1, sensors.Line2, sensors.Line3)
+}
+
+// VoltageSensors shall contain the voltage readings of a polyphase circuit.
+// Readings the circuit does not have are nil.
+type VoltageSensors struct {
+	// Line1ToLine2 shall contain the line-to-line voltage, in volts, between
+	// L1 and L2.
+	Line1ToLine2 *SensorExcerpt
+	// Line1ToNeutral shall contain the line-to-neutral voltage, in volts, for
+	// L1.
+	Line1ToNeutral *SensorExcerpt
+	// Line2ToLine3 shall contain the line-to-line voltage, in volts, between
+	// L2 and L3.
+	Line2ToLine3 *SensorExcerpt
+	// Line2ToNeutral shall contain the line-to-neutral voltage, in volts, for
+	// L2.
+	Line2ToNeutral *SensorExcerpt
+	// Line3ToLine1 shall contain the line-to-line voltage, in volts, between
+	// L3 and L1.
+	Line3ToLine1 *SensorExcerpt
+	// Line3ToNeutral shall contain the line-to-neutral voltage, in volts, for
+	// L3.
+	Line3ToNeutral *SensorExcerpt
+}
+
+// LinesToNeutral returns the line-to-neutral voltage readings of the
+// reported lines, in order from Line1 to Line3.
+func (sensors *VoltageSensors) LinesToNeutral() []SensorExcerpt {
+	return reportedExcerpts(sensors.Line1ToNeutral, sensors.Line2ToNeutral, sensors.Line3ToNeutral)
+}
+
+// EnergySensors shall contain the energy readings of a polyphase circuit.
+// Readings the circuit does not have are nil.
+type EnergySensors struct {
+	// Line1ToLine2 shall contain the energy, in kilowatt-hours, between L1
+	// and L2.
+	Line1ToLine2 *EnergyReading
+	// Line1ToNeutral shall contain the energy, in kilowatt-hours, for L1.
+	Line1ToNeutral *EnergyReading
+	// Line2ToLine3 shall contain the energy, in kilowatt-hours, between L2
+	// and L3.
+	Line2ToLine3 *EnergyReading
+	// Line2ToNeutral shall contain the energy, in kilowatt-hours, for L2.
+	Line2ToNeutral *EnergyReading
+	// Line3ToLine1 shall contain the energy, in kilowatt-hours, between L3
+	// and L1.
+	Line3ToLine1 *EnergyReading
+	// Line3ToNeutral shall contain the energy, in kilowatt-hours, for L3.
+	Line3ToNeutral *EnergyReading
+}
+
+// reportedExcerpts returns the excerpts that are not nil, in order.
+func reportedExcerpts(excerpts ...*SensorExcerpt) []SensorExcerpt {
+	var result []SensorExcerpt
+	for _, excerpt := range excerpts {
+		if excerpt != nil {
+			result = append(result, *excerpt)
+		}
+	}
+
+	return result
+}
+
+// Circuit shall represent an electrical circuit of a power distribution
+// unit, such as a mains input or a branch circuit feeding a set of outlets.
+type Circuit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BreakerState shall contain the state of the over current protection
+	// device, such as Normal or Tripped.
+	BreakerState string
+	// CircuitType shall contain the type of circuit.
+	CircuitType CircuitType
+	// CurrentAmps shall contain the current, in amperes, of the circuit as a
+	// whole.
+	CurrentAmps SensorExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hours, delivered
+	// through the circuit.
+	EnergykWh EnergyReading
+	// NominalVoltage shall contain the nominal voltage of the circuit.
+	NominalVoltage NominalVoltageType
+	// PhaseWiringType shall contain the number of ungrounded current-carrying
+	// conductors (phases) and the total number of conductors (wires).
+	PhaseWiringType PhaseWiringType
+	// PolyPhaseCurrentAmps shall contain the current readings of each line
+	// of the circuit.
+	PolyPhaseCurrentAmps CurrentSensors
+	// PolyPhaseEnergykWh shall contain the energy readings of each line of
+	// the circuit.
+	PolyPhaseEnergykWh EnergySensors
+	// PolyPhaseVoltage shall contain the voltage readings of each line of
+	// the circuit.
+	PolyPhaseVoltage VoltageSensors
+	// PowerState shall contain the power state of the circuit.
+	PowerState PowerState
+	// PowerWatts shall contain the power, in watts, delivered through the
+	// circuit.
+	PowerWatts PowerReading
+	// RatedCurrentAmps shall contain the rated maximum current, in amperes,
+	// allowed for this circuit.
+	RatedCurrentAmps float64
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Voltage shall contain the voltage, in volts, of the circuit as a whole.
+	Voltage SensorExcerpt
+	// VoltageType shall contain the type of voltage the circuit provides.
+	VoltageType VoltageType
+	// outlets are the links to the outlets fed by this circuit.
+	outlets []string
+}
+
+// UnmarshalJSON unmarshals a Circuit object from the raw JSON.
+func (circuit *Circuit) UnmarshalJSON(b []byte) error {
+	type temp Circuit
+	var t struct {
+		temp
+		Links struct {
+			Outlets common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*circuit = Circuit(t.temp)
+
+	// Extract the links to other entities for later
+	circuit.outlets = t.Links.Outlets.ToStrings()
+
+	return nil
+}
+
+// Outlets gets the outlets fed by this circuit.
+func (circuit *Circuit) Outlets() ([]*Outlet, error) {
+	var result []*Outlet
+
+	collectionError := common.NewCollectionError()
+	for _, outletLink := range circuit.outlets {
+		outlet, err := GetOutlet(circuit.Client, outletLink)
+		if err != nil {
+			collectionError.Failures[outletLink] = err
+		} else {
+			result = append(result, outlet)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// PhaseCurrentImbalance returns how far apart the line currents of the
+// circuit are, as the difference between the highest and the lowest line
+// current in percent of their average. False is returned unless at least two
+// lines report a current and the average is positive.
+func (circuit *Circuit) PhaseCurrentImbalance() (float64, bool) {
+	lines := circuit.PolyPhaseCurrentAmps.Lines()
+	if len(lines) < 2 {
+		return 0, false
+	}
+
+	min, max, sum := lines[0].Reading, lines[0].Reading, 0.0
+	for _, line := range lines {
+		if line.Reading < min {
+			min = line.Reading
+		}
+		if line.Reading > max {
+			max = line.Reading
+		}
+		sum += line.Reading
+	}
+
+	return percentOf(max-min, sum/float64(len(lines)))
+}
+
+// GetCircuit will get a Circuit instance from the service.
+func GetCircuit(c common.Client, uri string) (*Circuit, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var circuit Circuit
+	err = json.NewDecoder(resp.Body).Decode(&circuit)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	circuit.SetClient(c)
+	return &circuit, nil
+}
+
+// ListReferencedCircuits gets the collection of Circuit from a provided
+// reference.
+func ListReferencedCircuits(c common.Client, link string) ([]*Circuit, error) { //nolint:dupl
+	var result []*Circuit
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, circuitLink := range links.ItemLinks {
+		circuit, err := GetCircuit(c, circuitLink)
+		if err != nil {
+			collectionError.Failures[circuitLink] = err
+		} else {
+			result = append(result, circuit)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
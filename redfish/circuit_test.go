@@ -0,0 +1,150 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+var circuitBody = `{
+	"@odata.type": "#Circuit.v1_7_0.Circuit",
+	"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1",
+	"Id": "AC1",
+	"Name": "Mains Input Circuit 1",
+	"CircuitType": "Mains",
+	"PhaseWiringType": "ThreePhase5Wire",
+	"NominalVoltage": "AC400V",
+	"RatedCurrentAmps": 32,
+	"BreakerState": "Normal",
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"PowerWatts": {"Reading": 8260, "ApparentVA": 8260, "PowerFactor": 1},
+	"EnergykWh": {"Reading": 325675},
+	"PolyPhaseCurrentAmps": {
+		"Line1": {
+			"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/CurrentMains1L1",
+			"Reading": 13
+		},
+		"Line2": {"Reading": 12},
+		"Line3": {"Reading": 11},
+		"Neutral": {"Reading": 1.5}
+	},
+	"PolyPhaseVoltage": {
+		"Line1ToNeutral": {"Reading": 230.1},
+		"Line2ToNeutral": {"Reading": 229.6},
+		"Line3ToNeutral": {"Reading": 230.4},
+		"Line1ToLine2": {"Reading": 398.2}
+	},
+	"PolyPhaseEnergykWh": {
+		"Line1ToNeutral": {"Reading": 108567}
+	},
+	"Links": {
+		"Outlets": [{"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1"}]
+	}
+}`
+
+// TestCircuit tests the parsing of Circuit objects.
+func TestCircuit(t *testing.T) {
+	var result Circuit
+	err := json.NewDecoder(strings.NewReader(circuitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "AC1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CircuitType != MainsCircuitType || result.PhaseWiringType != ThreePhase5WirePhaseWiringType {
+		t.Errorf("Invalid circuit type: %s, %s", result.CircuitType, result.PhaseWiringType)
+	}
+
+	if result.NominalVoltage != AC400VNominalVoltageType {
+		t.Errorf("Invalid NominalVoltage: %s", result.NominalVoltage)
+	}
+
+	if lines := result.PolyPhaseCurrentAmps.Lines(); len(lines) != 3 || lines[2].Reading != 11 {
+		t.Errorf("Invalid line currents: %v", lines)
+	}
+
+	if volts := result.PolyPhaseVoltage.LinesToNeutral(); len(volts) != 3 || volts[1].Reading != 229.6 {
+		t.Errorf("Invalid line voltages: %v", volts)
+	}
+
+	if result.PolyPhaseVoltage.Line2ToLine3 != nil {
+		t.Error("Expected an unreported voltage to be nil")
+	}
+
+	if result.PolyPhaseEnergykWh.Line1ToNeutral.Reading != 108567 {
+		t.Errorf("Invalid line energy: %+v", result.PolyPhaseEnergykWh.Line1ToNeutral)
+	}
+
+	imbalance, ok := result.PhaseCurrentImbalance()
+	if !ok || math.Abs(imbalance-2.0/12*100) > 1e-9 {
+		t.Errorf("Invalid phase current imbalance: %g, %t", imbalance, ok)
+	}
+
+	result.PolyPhaseCurrentAmps.Line2 = nil
+	result.PolyPhaseCurrentAmps.Line3 = nil
+	if _, ok := result.PhaseCurrentImbalance(); ok {
+		t.Error("Expected no imbalance for a single phase")
+	}
+}
+
+// TestPowerDistributionCircuits tests reading the circuits of a PDU and the
+// sensors behind their readings.
+func TestPowerDistributionCircuits(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Mains", `{
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1"}]
+	}`)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1", circuitBody)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/CurrentMains1L1", `{
+		"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/CurrentMains1L1",
+		"Id": "CurrentMains1L1",
+		"ReadingType": "Current",
+		"Reading": 13.1,
+		"ReadingUnits": "A"
+	}`)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1", outletBody)
+
+	var pdu PowerDistribution
+	if err := json.Unmarshal([]byte(powerDistributionBody), &pdu); err != nil {
+		t.Fatalf("Error decoding PDU: %s", err)
+	}
+	pdu.SetClient(c)
+
+	branches, err := pdu.Branches()
+	if err == nil || len(branches) != 0 {
+		t.Errorf("Expected an error for the missing branches, got %d: %v", len(branches), err)
+	}
+
+	mains, err := pdu.Mains()
+	if err != nil {
+		t.Fatalf("Error getting mains: %s", err)
+	}
+	if len(mains) != 1 {
+		t.Fatalf("Expected 1 mains circuit, got %d", len(mains))
+	}
+
+	sensor, err := mains[0].PolyPhaseCurrentAmps.Line1.Sensor(c)
+	if err != nil {
+		t.Fatalf("Error getting line sensor: %s", err)
+	}
+	if sensor.Reading != 13.1 {
+		t.Errorf("Invalid sensor reading: %g", sensor.Reading)
+	}
+
+	outlets, err := mains[0].Outlets()
+	if err != nil || len(outlets) != 1 || outlets[0].ID != "A1" {
+		t.Errorf("Invalid outlets: %v, %v", outlets, err)
+	}
+}
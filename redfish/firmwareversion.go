@@ -0,0 +1,173 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FirmwareVersion is a firmware version string, such as the FirmwareVersion
+// of a power supply, parsed so it can be compared with another. Versions are
+// read as an optional vendor prefix, such as "v" or "FW", followed by dotted
+// numbers, such as "1.2.3", and an optional semantic versioning pre-release
+// ("-rc.1") and build metadata ("+20200101"). Anything else following the
+// numbers, such as the "a" of "1.2a", is kept as the Suffix.
+type FirmwareVersion struct {
+	// Prefix is the lower-cased vendor prefix, without a lone "v".
+	Prefix string
+	// Numbers is the dotted numbers of the version.
+	Numbers []int
+	// PreRelease is the semantic versioning pre-release, which sorts before
+	// the release with the same numbers.
+	PreRelease string
+	// Suffix is any other text following the numbers.
+	Suffix string
+}
+
+// ParseFirmwareVersion parses a firmware version string. An error is returned
+// if the version has no numbers to compare.
+func ParseFirmwareVersion(version string) (FirmwareVersion, error) {
+	var result FirmwareVersion
+
+	s := strings.TrimSpace(version)
+	start := strings.IndexFunc(s, unicode.IsDigit)
+	if start < 0 {
+		return result, fmt.Errorf("firmware version %q has no version number", version)
+	}
+
+	result.Prefix = strings.ToLower(strings.TrimRight(s[:start], " _-:."))
+	if result.Prefix == "v" {
+		result.Prefix = ""
+	}
+	s = s[start:]
+
+	for {
+		end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end < 0 {
+			end = len(s)
+		}
+		number, err := strconv.Atoi(s[:end])
+		if err != nil {
+			return result, fmt.Errorf("firmware version %q: %w", version, err)
+		}
+		result.Numbers = append(result.Numbers, number)
+		s = s[end:]
+
+		// Only carry on for a dot followed by another number
+		if len(s) < 2 || s[0] != '.' || !unicode.IsDigit(rune(s[1])) {
+			break
+		}
+		s = s[1:]
+	}
+
+	// Build metadata does not take part in comparisons
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if strings.HasPrefix(s, "-") && len(s) > 1 {
+		result.PreRelease = s[1:]
+	} else {
+		result.Suffix = s
+	}
+
+	return result, nil
+}
+
+// String returns the version in a normalized form.
+func (version FirmwareVersion) String() string {
+	var b strings.Builder
+	b.WriteString(version.Prefix)
+	for i, number := range version.Numbers {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.Itoa(number))
+	}
+	if version.PreRelease != "" {
+		b.WriteByte('-')
+		b.WriteString(version.PreRelease)
+	}
+	b.WriteString(version.Suffix)
+	return b.String()
+}
+
+// Compare returns -1, 0 or 1 as the version is older than, the same as or
+// newer than other. Missing numbers count as zero, so 1.2 is the same as
+// 1.2.0. An error is returned rather than guessing when the versions have
+// different vendor prefixes, or the same numbers but different suffixes.
+func (version FirmwareVersion) Compare(other FirmwareVersion) (int, error) {
+	if version.Prefix != other.Prefix {
+		return 0, fmt.Errorf("firmware versions %s and %s have different prefixes", version, other)
+	}
+
+	for i := 0; i < len(version.Numbers) || i < len(other.Numbers); i++ {
+		var a, b int
+		if i < len(version.Numbers) {
+			a = version.Numbers[i]
+		}
+		if i < len(other.Numbers) {
+			b = other.Numbers[i]
+		}
+		if a != b {
+			return compareInts(a, b), nil
+		}
+	}
+
+	if version.Suffix != other.Suffix {
+		return 0, fmt.Errorf("firmware versions %s and %s cannot be compared", version, other)
+	}
+
+	return comparePreReleases(version.PreRelease, other.PreRelease), nil
+}
+
+// comparePreReleases compares semantic versioning pre-releases. A release
+// without one sorts after those with one, and the dot separated identifiers
+// are compared numerically if they are numbers and lexically otherwise.
+func comparePreReleases(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aFields := strings.Split(a, ".")
+	bFields := strings.Split(b, ".")
+	for i := 0; i < len(aFields) && i < len(bFields); i++ {
+		if aFields[i] == bFields[i] {
+			continue
+		}
+		aNumber, aErr := strconv.Atoi(aFields[i])
+		bNumber, bErr := strconv.Atoi(bFields[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			return compareInts(aNumber, bNumber)
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			return strings.Compare(aFields[i], bFields[i])
+		}
+	}
+
+	return compareInts(len(aFields), len(bFields))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import "testing"
+
+// TestFirmwareVersionCompare tests parsing and comparing firmware versions.
+func TestFirmwareVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		order int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"v2.0", "1.9.9", 1},
+		{"FW 00.23", "fw-0.22", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.0.0-alpha", "1.0.0-1", 1},
+		{"1.0.0+build.7", "1.0.0", 0},
+		{"1.2a", "1.3b", -1},
+	}
+
+	for _, test := range tests {
+		a, err := ParseFirmwareVersion(test.a)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %s", test.a, err)
+		}
+		b, err := ParseFirmwareVersion(test.b)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %s", test.b, err)
+		}
+
+		order, err := a.Compare(b)
+		if err != nil || order != test.order {
+			t.Errorf("Comparing %s to %s: expected %d, got %d: %v", test.a, test.b, test.order, order, err)
+		}
+	}
+
+	for _, pair := range [][2]string{{"1.2a", "1.2b"}, {"1.2", "1.2a"}, {"A1.0", "B1.0"}} {
+		a, _ := ParseFirmwareVersion(pair[0])
+		b, _ := ParseFirmwareVersion(pair[1])
+		if _, err := a.Compare(b); err == nil {
+			t.Errorf("Expected %s and %s not to be comparable", pair[0], pair[1])
+		}
+	}
+
+	if _, err := ParseFirmwareVersion("unknown"); err == nil {
+		t.Errorf("Expected an error parsing a version without numbers")
+	}
+}
+
+// TestPowerSupplyFirmwareOlderThan tests comparing the firmware of a supply.
+func TestPowerSupplyFirmwareOlderThan(t *testing.T) {
+	supply := PowerSupply{MemberID: "0", FirmwareVersion: "1.00"}
+
+	older, err := supply.FirmwareOlderThan("1.01")
+	if err != nil || !older {
+		t.Errorf("Expected 1.00 to be older than 1.01: %v", err)
+	}
+	older, err = supply.FirmwareOlderThan("1.0")
+	if err != nil || older {
+		t.Errorf("Expected 1.00 not to be older than 1.0: %v", err)
+	}
+
+	if _, err := supply.FirmwareOlderThan("A1.01"); err == nil {
+		t.Errorf("Expected an error comparing differently prefixed versions")
+	}
+	supply.FirmwareVersion = ""
+	if _, err := supply.FirmwareOlderThan("1.01"); err == nil {
+		t.Errorf("Expected an error without a firmware version")
+	}
+}
@@ -0,0 +1,228 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// PowerCyclePowerState requests that an outlet or circuit be powered off and
+// then on again. It is only used with power control actions and is never
+// reported as the current power state.
+const PowerCyclePowerState PowerState = "PowerCycle"
+
+// VoltageType is the type of voltage an outlet or circuit provides.
+type VoltageType string
+
+const (
+
+	// ACVoltageType Alternating Current (AC) outlet.
+	ACVoltageType VoltageType = "AC"
+	// DCVoltageType Direct Current (DC) outlet.
+	DCVoltageType VoltageType = "DC"
+)
+
+// Outlet shall represent an electrical outlet of a power distribution unit
+// for a Redfish implementation.
+type Outlet struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CurrentAmps shall contain the current, in amperes, flowing through the
+	// outlet.
+	CurrentAmps SensorExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hours, delivered
+	// through the outlet.
+	EnergykWh EnergyReading
+	// IndicatorLED shall contain the indicator light state for the indicator
+	// light associated with this outlet.
+	IndicatorLED common.IndicatorLED
+	// OutletType shall contain the type of physical receptacle used for this
+	// outlet, such as NEMA_5_20R.
+	OutletType string
+	// PowerCycleDelaySeconds shall contain the number of seconds to delay
+	// power on after a PowerControl action to cycle power.
+	PowerCycleDelaySeconds float64
+	// PowerEnabled shall indicate the power enable state of the outlet. True
+	// shall indicate that the outlet can be powered on, and false shall
+	// indicate that the outlet cannot be powered.
+	PowerEnabled bool
+	// PowerOffDelaySeconds shall contain the number of seconds to delay
+	// power off after a PowerControl action.
+	PowerOffDelaySeconds float64
+	// PowerOnDelaySeconds shall contain the number of seconds to delay power
+	// up after a power cycle or a PowerControl action.
+	PowerOnDelaySeconds float64
+	// PowerState shall contain the power state of the outlet.
+	PowerState PowerState
+	// PowerWatts shall contain the power, in watts, delivered through the
+	// outlet.
+	PowerWatts PowerReading
+	// RatedCurrentAmps shall contain the rated maximum current, in amperes,
+	// allowed for this outlet.
+	RatedCurrentAmps float64
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Voltage shall contain the voltage, in volts, of the outlet.
+	Voltage SensorExcerpt
+	// VoltageType shall contain the type of voltage the outlet provides.
+	VoltageType VoltageType
+	// SupportedPowerStates, if provided, is the power states the PowerControl
+	// action of this outlet accepts.
+	SupportedPowerStates []PowerState
+	// powerControlTarget is the URL to send PowerControl requests to.
+	powerControlTarget string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals an Outlet object from the raw JSON.
+func (outlet *Outlet) UnmarshalJSON(b []byte) error {
+	type temp Outlet
+	var t struct {
+		temp
+		Actions struct {
+			PowerControl struct {
+				AllowedPowerStates []PowerState `json:"PowerState@Redfish.AllowableValues"`
+				Target             string
+			} `json:"#Outlet.PowerControl"`
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*outlet = Outlet(t.temp)
+
+	// Extract the links to other entities for later
+	outlet.powerControlTarget = t.Actions.PowerControl.Target
+	outlet.SupportedPowerStates = t.Actions.PowerControl.AllowedPowerStates
+
+	// This is a read/write object, so we need to save the raw object data for later
+	outlet.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (outlet *Outlet) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Outlet)
+	err := original.UnmarshalJSON(outlet.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"PowerCycleDelaySeconds",
+		"PowerOffDelaySeconds",
+		"PowerOnDelaySeconds",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(outlet).Elem()
+
+	return outlet.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// PowerControl turns the outlet on or off, or cycles its power, with
+// OnPowerState, OffPowerState or PowerCyclePowerState. If the service lists
+// the power states the action accepts, other states are rejected without a
+// request.
+func (outlet *Outlet) PowerControl(state PowerState) error {
+	if outlet.powerControlTarget == "" {
+		return fmt.Errorf("PowerControl is not supported by outlet %s", outlet.ODataID)
+	}
+
+	// Make sure the requested power state is supported by the outlet
+	valid := len(outlet.SupportedPowerStates) == 0
+	for _, allowed := range outlet.SupportedPowerStates {
+		if state == allowed {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("power state '%s' is not supported by this outlet", state)
+	}
+
+	t := struct {
+		PowerState PowerState
+	}{
+		PowerState: state,
+	}
+
+	resp, err := outlet.Client.Post(outlet.powerControlTarget, t)
+	if err != nil {
+		return common.ClassifyError(outlet.powerControlTarget, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// GetOutlet will get an Outlet instance from the service.
+func GetOutlet(c common.Client, uri string) (*Outlet, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var outlet Outlet
+	err = json.NewDecoder(resp.Body).Decode(&outlet)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	outlet.SetClient(c)
+	return &outlet, nil
+}
+
+// ListReferencedOutlets gets the collection of Outlet from a provided
+// reference.
+func ListReferencedOutlets(c common.Client, link string) ([]*Outlet, error) { //nolint:dupl
+	var result []*Outlet
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, outletLink := range links.ItemLinks {
+		outlet, err := GetOutlet(c, outletLink)
+		if err != nil {
+			collectionError.Failures[outletLink] = err
+		} else {
+			result = append(result, outlet)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
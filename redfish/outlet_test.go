@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+var outletBody = `{
+	"@odata.type": "#Outlet.v1_4_0.Outlet",
+	"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1",
+	"Id": "A1",
+	"Name": "Outlet A1, Branch Circuit A",
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"OutletType": "NEMA_5_20R",
+	"RatedCurrentAmps": 20,
+	"VoltageType": "AC",
+	"IndicatorLED": "Lit",
+	"PowerOnDelaySeconds": 4,
+	"PowerOffDelaySeconds": 0,
+	"PowerState": "On",
+	"PowerEnabled": true,
+	"Voltage": {
+		"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/VoltageA1",
+		"Reading": 117.5
+	},
+	"CurrentAmps": {"Reading": 1.68},
+	"PowerWatts": {"Reading": 197.4, "ApparentVA": 197.4, "PowerFactor": 1},
+	"EnergykWh": {"Reading": 36166},
+	"Actions": {
+		"#Outlet.PowerControl": {
+			"target": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1/Outlet.PowerControl",
+			"PowerState@Redfish.AllowableValues": ["On", "Off"]
+		}
+	}
+}`
+
+// TestOutlet tests the parsing of Outlet objects.
+func TestOutlet(t *testing.T) {
+	var result Outlet
+	err := json.NewDecoder(strings.NewReader(outletBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "A1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.VoltageType != ACVoltageType {
+		t.Errorf("Invalid VoltageType: %s", result.VoltageType)
+	}
+
+	if result.PowerState != OnPowerState {
+		t.Errorf("Invalid PowerState: %s", result.PowerState)
+	}
+
+	if result.RatedCurrentAmps != 20 {
+		t.Errorf("Invalid RatedCurrentAmps: %g", result.RatedCurrentAmps)
+	}
+
+	if result.Voltage.Reading != 117.5 || result.PowerWatts.Reading != 197.4 {
+		t.Errorf("Invalid readings: %+v, %+v", result.Voltage, result.PowerWatts)
+	}
+
+	if result.powerControlTarget != "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1/Outlet.PowerControl" {
+		t.Errorf("Invalid PowerControl target: %s", result.powerControlTarget)
+	}
+
+	if len(result.SupportedPowerStates) != 2 {
+		t.Errorf("Invalid SupportedPowerStates: %v", result.SupportedPowerStates)
+	}
+}
+
+// TestOutletPowerControl tests switching an outlet.
+func TestOutletPowerControl(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Outlets", `{
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1"}]
+	}`)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1", outletBody)
+
+	pdu := &PowerDistribution{outlets: "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets"}
+	pdu.SetClient(c)
+
+	outlets, err := pdu.Outlets()
+	if err != nil {
+		t.Fatalf("Error getting outlets: %s", err)
+	}
+	if len(outlets) != 1 {
+		t.Fatalf("Expected 1 outlet, got %d", len(outlets))
+	}
+	outlet := outlets[0]
+
+	if err := outlet.PowerControl(OffPowerState); err != nil {
+		t.Errorf("Error switching outlet off: %s", err)
+	}
+	bodies := c.PostBodies(outlet.powerControlTarget)
+	if len(bodies) != 1 || !strings.Contains(bodies[0], `"PowerState":"Off"`) {
+		t.Errorf("Invalid PowerControl bodies: %v", bodies)
+	}
+
+	if err := outlet.PowerControl(PowerCyclePowerState); err == nil {
+		t.Error("Expected an error for a power state the outlet does not allow")
+	}
+	if len(c.PostBodies(outlet.powerControlTarget)) != 1 {
+		t.Error("Expected no request for a rejected power state")
+	}
+
+	outlet.SupportedPowerStates = nil
+	if err := outlet.PowerControl(PowerCyclePowerState); err != nil {
+		t.Errorf("Error cycling outlet: %s", err)
+	}
+}
@@ -4,15 +4,21 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
+	"sort"
 	"strconv"
 	"fmt"
 	"bytes"
-	"io"
 	"io/ioutil"
-	"os"
+	"math"
+	"net/http"
+	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ciferlu1024/gofish/common"
 )
@@ -60,6 +66,45 @@ const (
 	DC240VLineInputVoltageType LineInputVoltageType = "DC240V"
 )
 
+// nominalLineInputVoltages maps line input voltage types to their nominal
+// voltage. Wide range and unknown types have no nominal voltage.
+var nominalLineInputVoltages = map[LineInputVoltageType]float64{
+	ACLowLineLineInputVoltageType:  120,
+	ACMidLineLineInputVoltageType:  230,
+	ACHighLineLineInputVoltageType: 277,
+	DCNeg48VLineInputVoltageType:   -48,
+	DC380VLineInputVoltageType:     380,
+	AC120VLineInputVoltageType:     120,
+	AC240VLineInputVoltageType:     240,
+	AC277VLineInputVoltageType:     277,
+	DC240VLineInputVoltageType:     240,
+}
+
+// IsKnown reports whether the line input voltage type is one of the values
+// defined by the schema. Services sometimes report vendor extensions, which
+// are kept as reported when decoding.
+func (t LineInputVoltageType) IsKnown() bool {
+	switch t {
+	case UnknownLineInputVoltageType, ACLowLineLineInputVoltageType,
+		ACMidLineLineInputVoltageType, ACHighLineLineInputVoltageType,
+		DCNeg48VLineInputVoltageType, DC380VLineInputVoltageType,
+		AC120VLineInputVoltageType, AC240VLineInputVoltageType,
+		AC277VLineInputVoltageType, ACandDCWideRangeLineInputVoltageType,
+		ACWideRangeLineInputVoltageType, DC240VLineInputVoltageType:
+		return true
+	}
+	return false
+}
+
+// NominalVolts returns the nominal voltage of the line input voltage type,
+// such as 120 for AC120V. For the low and mid line AC ranges the common
+// nominal values of 120V and 230V are used. False is returned for wide
+// range, unknown and unrecognized types.
+func (t LineInputVoltageType) NominalVolts() (float64, bool) {
+	nominal, ok := nominalLineInputVoltages[t]
+	return nominal, ok
+}
+
 // PowerLimitException is the type of power limit exception.
 type PowerLimitException string
 
@@ -77,6 +122,34 @@ const (
 	OemPowerLimitException PowerLimitException = "Oem"
 )
 
+// VoltageThresholdState is the position of a voltage reading relative to the
+// thresholds of its sensor.
+type VoltageThresholdState string
+
+const (
+
+	// NormalVoltageThresholdState The reading has not reached any threshold.
+	NormalVoltageThresholdState VoltageThresholdState = "Normal"
+	// LowerNonCriticalVoltageThresholdState The reading is at or below the
+	// LowerThresholdNonCritical.
+	LowerNonCriticalVoltageThresholdState VoltageThresholdState = "LowerNonCritical"
+	// LowerCriticalVoltageThresholdState The reading is at or below the
+	// LowerThresholdCritical.
+	LowerCriticalVoltageThresholdState VoltageThresholdState = "LowerCritical"
+	// LowerFatalVoltageThresholdState The reading is at or below the
+	// LowerThresholdFatal.
+	LowerFatalVoltageThresholdState VoltageThresholdState = "LowerFatal"
+	// UpperNonCriticalVoltageThresholdState The reading is at or above the
+	// UpperThresholdNonCritical.
+	UpperNonCriticalVoltageThresholdState VoltageThresholdState = "UpperNonCritical"
+	// UpperCriticalVoltageThresholdState The reading is at or above the
+	// UpperThresholdCritical.
+	UpperCriticalVoltageThresholdState VoltageThresholdState = "UpperCritical"
+	// UpperFatalVoltageThresholdState The reading is at or above the
+	// UpperThresholdFatal.
+	UpperFatalVoltageThresholdState VoltageThresholdState = "UpperFatal"
+)
+
 // PowerSupplyType is the type of power supply.
 type PowerSupplyType string
 
@@ -114,6 +187,90 @@ type InputRange struct {
 	OutputWattage float64
 }
 
+// physicalContextAliases maps the lower cased form of a physical context, with
+// any separators removed, to its canonical value. Alongside the standard values
+// it includes spellings seen from various vendors.
+var physicalContextAliases = map[string]common.PhysicalContext{
+	"room":                     common.RoomPhysicalContext,
+	"intake":                   common.IntakePhysicalContext,
+	"exhaust":                  common.ExhaustPhysicalContext,
+	"liquidinlet":              common.LiquidInletPhysicalContext,
+	"liquidoutlet":             common.LiquidOutletPhysicalContext,
+	"front":                    common.FrontPhysicalContext,
+	"back":                     common.BackPhysicalContext,
+	"upper":                    common.UpperPhysicalContext,
+	"lower":                    common.LowerPhysicalContext,
+	"cpu":                      common.CPUPhysicalContext,
+	"cpusubsystem":             common.CPUSubsystemPhysicalContext,
+	"gpu":                      common.GPUPhysicalContext,
+	"gpusubsystem":             common.GPUSubsystemPhysicalContext,
+	"fpga":                     common.FPGAPhysicalContext,
+	"accelerator":              common.AcceleratorPhysicalContext,
+	"asic":                     common.ASICPhysicalContext,
+	"backplane":                common.BackplanePhysicalContext,
+	"systemboard":              common.SystemBoardPhysicalContext,
+	"powersupply":              common.PowerSupplyPhysicalContext,
+	"powersubsystem":           common.PowerSubsystemPhysicalContext,
+	"voltageregulator":         common.VoltageRegulatorPhysicalContext,
+	"rectifier":                common.RectifierPhysicalContext,
+	"storagedevice":            common.StorageDevicePhysicalContext,
+	"networkingdevice":         common.NetworkingDevicePhysicalContext,
+	"computebay":               common.ComputeBayPhysicalContext,
+	"storagebay":               common.StorageBayPhysicalContext,
+	"networkbay":               common.NetworkBayPhysicalContext,
+	"expansionbay":             common.ExpansionBayPhysicalContext,
+	"powersupplybay":           common.PowerSupplyBayPhysicalContext,
+	"memory":                   common.MemoryPhysicalContext,
+	"memorysubsystem":          common.MemorySubsystemPhysicalContext,
+	"chassis":                  common.ChassisPhysicalContext,
+	"fan":                      common.FanPhysicalContext,
+	"coolingsubsystem":         common.CoolingSubsystemPhysicalContext,
+	"motor":                    common.MotorPhysicalContext,
+	"transformer":              common.TransformerPhysicalContext,
+	"acutilityinput":           common.ACUtilityInputPhysicalContext,
+	"acstaticbypassinput":      common.ACStaticBypassInputPhysicalContext,
+	"acmaintenancebypassinput": common.ACMaintenanceBypassInputPhysicalContext,
+	"dcbus":                    common.DCBusPhysicalContext,
+	"acoutput":                 common.ACOutputPhysicalContext,
+	"acinput":                  common.ACInputPhysicalContext,
+
+	// Vendor spellings
+	"processor":   common.CPUPhysicalContext,
+	"motherboard": common.SystemBoardPhysicalContext,
+	"mainboard":   common.SystemBoardPhysicalContext,
+	"psu":         common.PowerSupplyPhysicalContext,
+	"vrm":         common.VoltageRegulatorPhysicalContext,
+	"dimm":        common.MemoryPhysicalContext,
+}
+
+// NormalizePhysicalContext maps the physical context strings returned by
+// different implementations, such as "Cpu" or "Processor", to the matching
+// common.PhysicalContext value. Unrecognized values are returned unchanged.
+func NormalizePhysicalContext(s string) common.PhysicalContext {
+	if s == "" {
+		return ""
+	}
+
+	key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s))
+	if physicalContext, ok := physicalContextAliases[key]; ok {
+		return physicalContext
+	}
+
+	common.GetLogger().Warnf("gofish: unrecognized physical context %q", s)
+	return common.PhysicalContext(s)
+}
+
+// decodePhysicalContext normalizes a raw PhysicalContext property. Values
+// that are not strings, which some services send for unknown contexts, are
+// treated as not reported.
+func decodePhysicalContext(b json.RawMessage) common.PhysicalContext {
+	var s string
+	if len(b) == 0 || json.Unmarshal(b, &s) != nil {
+		return ""
+	}
+	return NormalizePhysicalContext(s)
+}
+
 // Power is used to represent a power metrics resource for a Redfish
 // implementation.
 type Power struct {
@@ -121,6 +278,9 @@ type Power struct {
 
 	// ODataContext is the odata context.
 	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the entity tag of the resource, if the service reports
+	// one in the body.
+	ODataEtag string `json:"@odata.etag"`
 	// ODataType is the odata type.
 	ODataType string `json:"@odata.type"`
 	// Description provides a description of this resource.
@@ -128,6 +288,9 @@ type Power struct {
 	// IndicatorLED shall contain the indicator light state for the indicator
 	// light associated with this power supply.
 	IndicatorLED common.IndicatorLED
+	// Oem contains the raw vendor specific properties of this resource. The
+	// oem subpackages provide helpers for reading them.
+	Oem json.RawMessage
 	// PowerControl shall be the definition for power control (power reading and
 	// limiting) for a Redfish implementation.
 	PowerControl []PowerControl
@@ -148,197 +311,2699 @@ type Power struct {
 	Voltages []Voltage
 	// VoltagesCount is the number of objects.
 	VoltagesCount int `json:"Voltages@odata.count"`
+	// DecodeWarnings lists the array elements that were malformed and were
+	// skipped when decoding this resource.
+	DecodeWarnings []common.DecodeWarning `json:"-"`
+	// SupportedPowerSupplyResetTypes, if provided, is the reset types the
+	// PowerSupplyReset action accepts.
+	SupportedPowerSupplyResetTypes []ResetType `json:"-"`
+
+	// logService and logServices are links to log resources recording
+	// events for this resource, where the service provides them.
+	logService  string
+	logServices string
+	metrics     string
+	// etag is the entity tag of the representation this resource was read
+	// from.
+	etag string
+	// numbers holds the original text of each number in the response, keyed
+	// by its JSON pointer, if the client is configured to keep them.
+	numbers map[string]json.Number
+	// allowedMethods caches the Allow header of the response this resource
+	// was read from.
+	allowedMethods []string
+	// actions holds the raw Actions object, including any vendor specific
+	// actions under Oem.
+	actions json.RawMessage
+	// powerSupplyResetTarget is the URL to send PowerSupplyReset requests to.
+	powerSupplyResetTarget string
+}
+
+// UnmarshalJSON unmarshals a Power object from the raw JSON.
+func (power *Power) UnmarshalJSON(b []byte) error {
+	type temp Power
+	var t struct {
+		temp
+		Actions json.RawMessage
+		Links   struct {
+			LogService  common.Link
+			LogServices common.Link
+		}
+		Metrics       common.Link
+		PowerControl  json.RawMessage
+		PowerMetrics  common.Link
+		PowerSupplies []json.RawMessage
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	var actions struct {
+		PowerSupplyReset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#Power.PowerSupplyReset"`
+	}
+	if len(t.Actions) > 0 && string(t.Actions) != "null" {
+		if err := json.Unmarshal(t.Actions, &actions); err != nil {
+			return err
+		}
+	}
+
+	powerControl, err := decodePowerControl(t.PowerControl)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*power = Power(t.temp)
+	power.PowerControl = powerControl
+	power.logService = string(t.Links.LogService)
+	power.logServices = string(t.Links.LogServices)
+	power.metrics = string(t.PowerMetrics)
+	if power.metrics == "" {
+		power.metrics = string(t.Metrics)
+	}
+	power.actions = t.Actions
+	power.powerSupplyResetTarget = actions.PowerSupplyReset.Target
+	power.SupportedPowerSupplyResetTypes = actions.PowerSupplyReset.AllowedResetTypes
+
+	// Decode the supplies one at a time so a single malformed entry does not
+	// hide the others
+	for i, raw := range t.PowerSupplies {
+		var supply PowerSupply
+		if err := json.Unmarshal(raw, &supply); err != nil {
+			power.DecodeWarnings = append(power.DecodeWarnings, common.DecodeWarning{
+				Property: "PowerSupplies",
+				Index:    i,
+				Err:      err,
+			})
+			continue
+		}
+		power.PowerSupplies = append(power.PowerSupplies, supply)
+	}
+
+	// Record how many members of each redundancy group are online so the
+	// groups can be judged on their own
+	if len(power.PowerSupplies) > 0 {
+		for i := range power.Redundancy {
+			redundancy := &power.Redundancy[i]
+			redundancy.onlineMembers = power.onlineRedundancyMembers(redundancy)
+			redundancy.membersKnown = true
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON marshals the resource back into a Redfish shaped document, such
+// as for snapshots or test fixtures, that decodes into an equivalent Power.
+// The @odata.count properties are filled in from the length of their arrays
+// when not set, and the links to the log services and metrics and the
+// actions are kept.
+func (power *Power) MarshalJSON() ([]byte, error) {
+	type temp Power
+	type reference struct {
+		ODataID string `json:"@odata.id"`
+	}
+	toReference := func(uri string) *reference {
+		if uri == "" {
+			return nil
+		}
+		return &reference{ODataID: uri}
+	}
+
+	t := struct {
+		temp
+		Actions json.RawMessage `json:",omitempty"`
+		Oem     json.RawMessage `json:",omitempty"`
+		Links   struct {
+			LogService  *reference `json:",omitempty"`
+			LogServices *reference `json:",omitempty"`
+		}
+		PowerMetrics *reference `json:",omitempty"`
+	}{
+		temp:         temp(*power),
+		Actions:      power.actions,
+		Oem:          power.Oem,
+		PowerMetrics: toReference(power.metrics),
+	}
+	t.Links.LogService = toReference(power.logService)
+	t.Links.LogServices = toReference(power.logServices)
+
+	if t.PowerControlCount == 0 {
+		t.PowerControlCount = len(power.PowerControl)
+	}
+	if t.PowerSuppliesCount == 0 {
+		t.PowerSuppliesCount = len(power.PowerSupplies)
+	}
+	if t.RedundancyCount == 0 {
+		t.RedundancyCount = len(power.Redundancy)
+	}
+	if t.VoltagesCount == 0 {
+		t.VoltagesCount = len(power.Voltages)
+	}
+
+	return json.Marshal(t)
+}
+
+// decodePowerControl decodes the PowerControl property, which some services
+// return as a single object instead of an array of them.
+func decodePowerControl(b json.RawMessage) ([]PowerControl, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	if isJSONObject(b) {
+		var powerControl PowerControl
+		if err := json.Unmarshal(b, &powerControl); err != nil {
+			return nil, err
+		}
+		return []PowerControl{powerControl}, nil
+	}
+
+	var powerControls []PowerControl
+	if err := json.Unmarshal(b, &powerControls); err != nil {
+		return nil, err
+	}
+	return powerControls, nil
+}
+
+// isJSONObject reports whether b holds a JSON object.
+func isJSONObject(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '{'
 }
 
 // GetPower will get a Power instance from the service.
 func GetPower(c common.Client, uri string) (*Power, error) {
-	fmt.Println("******************power.go getpower", uri)
+	return GetPowerWithContext(context.Background(), c, uri)
+}
+
+// GetPowerWithContext will get a Power instance from the service, giving up
+// when ctx is done.
+func GetPowerWithContext(ctx context.Context, c common.Client, uri string) (*Power, error) {
+	common.GetLogger().Debugf("gofish: getting power %s", uri)
+	resp, err := common.GetWithContext(ctx, c, uri)
+	if err != nil {
+		common.GetLogger().Errorf("gofish: getting power %s: %v", uri, err)
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	return parsePower(c, uri, resp)
+}
+
+// ParsePower decodes a Power resource from data already read, such as from a
+// cache or a message bus, with the same tolerance for service quirks as
+// GetPower. The resource has no client, so methods that follow its links or
+// send updates fail with common.ErrNoClient until SetClient is called.
+func ParsePower(data []byte) (*Power, error) {
+	return decodePower(common.DetachedClient{}, "", data, http.Header{})
+}
+
+// ParsePowerControl decodes a single member of the PowerControl array of a
+// Power resource from data already read. As for ParsePower, the control has
+// no client.
+func ParsePowerControl(data []byte) (*PowerControl, error) {
+	var powercontrol PowerControl
+	err := json.Unmarshal(data, &powercontrol)
+	if err != nil {
+		return nil, &common.ParseError{Err: err}
+	}
+
+	powercontrol.SetClient(common.DetachedClient{})
+	return &powercontrol, nil
+}
+
+// ParseVoltage decodes a single member of the Voltages array of a Power
+// resource from data already read. As for ParsePower, the voltage has no
+// client.
+func ParseVoltage(data []byte) (*Voltage, error) {
+	var voltage Voltage
+	err := json.Unmarshal(data, &voltage)
+	if err != nil {
+		return nil, &common.ParseError{Err: err}
+	}
+
+	voltage.SetClient(common.DetachedClient{})
+	return &voltage, nil
+}
+
+// GetPowerWithOptions will get a Power instance from the service, adding the
+// parameters of query, such as a filter or expansion, to the request.
+func GetPowerWithOptions(c common.Client, uri string, query *common.Query) (*Power, error) {
+	uri = query.Apply(uri)
 	resp, err := c.Get(uri)
 	if err != nil {
-		fmt.Println("*********************power.go getpower get 报错！", err)
-		return nil, err
-	}else{
-		fmt.Println("*********************power.go getpower get 没有报错！")
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	return parsePower(c, uri, resp)
+}
+
+// GetPowerSelect will get a Power instance from the service, asking it with
+// $select to return only the given properties, such as "PowerControl". The
+// properties that were not selected are left empty. A service that ignores
+// $select returns the full resource, which is decoded as usual.
+func GetPowerSelect(c common.Client, uri string, properties []string) (*Power, error) {
+	if len(properties) == 0 {
+		return GetPower(c, uri)
 	}
+
+	return GetPowerWithOptions(c, uri, common.NewQuery().WithSelect(properties...))
+}
+
+// parsePower decodes a Power resource from the response to a GET of uri.
+func parsePower(c common.Client, uri string, resp *http.Response) (*Power, error) {
 	defer resp.Body.Close()
 
-	// os.Stdout 输出原始json内容!
-        mybodys, _ := ioutil.ReadAll(resp.Body)
-        var out bytes.Buffer
-        err = json.Indent(&out, mybodys, "", "\t")
-        if err != nil {
-                fmt.Println("**************************power.go json body 报错!", err)
-        }else{
-                fmt.Println("**************************power.go json body: 已获取\n")
-        }
-        //out.WriteTo(os.Stdout)
-
-        file, _ := os.Create("/tmp/powerjson.txt")
-        defer file.Close()
-        out.WriteTo(file)
-
-        // 读取json文件获取json数据
-        jsonFile, err := os.Open("/tmp/powerjson.txt")
-        if err != nil {
-                fmt.Println("error opening power json file")
-        }else{
-                fmt.Println("已打开power json文件")
-        }
-
-        defer jsonFile.Close()
-        jsonData, err := ioutil.ReadAll(jsonFile)
-        if err!= nil {
-                fmt.Println("error reading power json file")
-        }else{
-                fmt.Println("已读取power json数据")
-        }
-
-        // 重新解析json数据
-
-        var r interface{}
-        err = json.Unmarshal(jsonData, &r)
-        // fmt.Println("r的值：", r)
-
-        // 修改json数据部分字段的格式
-        newbodymap, _ := r.(map[string]interface{})
-
-//	var a float64 = 0
-//	var b string = "0"
-//	newbodymap["PowerControl"].(map[string]interface{})["PowerCapacityWatts"] = a
-//	newbodymap["PowerControl"].(map[string]interface{})["PowerLimit"].(map[string]interface{})["LimitInWatts"] = a
-//	newbodymap["PowerControl"].(map[string]interface{})["PowerLimit"].(map[string]interface{})["LimitException"] = b
-//	newbodymap["PowerControl"].(map[string]interface{})["PowerConsumedWatts"] = strconv.FormatFloat(newbodymap["PowerControl"].(map[string]interface{})["PowerConsumedWatts"].(float64), 'f', -1, 64)
-
-//	delete(newbodymap["PowerControl"].(map[string]interface{}), "PowerConsumedWatts")
-//	delete(newbodymap["PowerControl"].(map[string]interface{}), "PowerLimit")
-//	delete(newbodymap["PowerControl"].(map[string]interface{}), "PowerCapacityWatts")
-	delete(newbodymap, "PowerControl")
-	//fmt.Printf("powercontrol的值:%v , 类型:%T \n", newbodymap["PowerControl"], newbodymap["PowerControl"])
-
-
-        newbodyjson, err := json.Marshal(newbodymap)
-        if err != nil {
-                fmt.Println("*************newbodyjson err:", err)
-        }
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &common.TransportError{URI: uri, Err: err}
+	}
+
+	return decodePower(c, uri, body, resp.Header)
+}
+
+// decodePower decodes a Power resource from its body, as read from uri with
+// the given response header.
+func decodePower(c common.Client, uri string, body []byte, header http.Header) (*Power, error) {
+	// The schema requires PowerControl to be an array, but some services
+	// return a single object. This is tolerated unless the client asks for
+	// strict schema handling.
+	if common.IsStrictSchema(c) {
+		var shape struct {
+			PowerControl json.RawMessage
+		}
+		if json.Unmarshal(body, &shape) == nil && isJSONObject(shape.PowerControl) {
+			return nil, &common.ParseError{URI: uri, Err: fmt.Errorf("%w: PowerControl is an object, not an array", common.ErrSchemaViolation)}
+		}
+	}
 
 	var power Power
-	var newjsonreader io.Reader
-	newjsonreader = strings.NewReader(string(newbodyjson))
-	err = json.NewDecoder(newjsonreader).Decode(&power)
-	//err = json.NewDecoder(resp.Body).Decode(&power)
+	err := json.Unmarshal(body, &power)
 	if err != nil {
-		return nil, err
+		return nil, &common.ParseError{URI: uri, Err: err}
+	}
+
+	// Catch misrouted responses rather than returning a half-empty object
+	err = common.CheckResourceType(power.ODataType, power.ODataContext, "Power", "PowerSubsystem")
+	if err != nil {
+		return nil, &common.ParseError{URI: uri, Err: err}
 	}
 
 	power.SetClient(c)
+	power.allowedMethods = parseAllowHeader(header.Get("Allow"))
+	power.etag = header.Get("ETag")
+	if power.etag == "" {
+		power.etag = power.ODataEtag
+	}
+	if common.IsUseNumber(c) {
+		power.numbers = collectNumbers(body)
+	}
+	for i := range power.PowerControl {
+		power.PowerControl[i].SetClient(c)
+		power.PowerControl[i].allowedMethods = power.allowedMethods
+	}
+	for i := range power.PowerSupplies {
+		power.PowerSupplies[i].SetClient(c)
+		// The supplies are updated through the Power resource, so its
+		// entity tag applies
+		if power.etag != "" {
+			power.PowerSupplies[i].etag = power.etag
+		}
+	}
 	return &power, nil
 }
 
-// ListReferencedPowers gets the collection of Power from
-// a provided reference.
-func ListReferencedPowers(c common.Client, link string) ([]*Power, error) { //nolint:dupl
-	var result []*Power
-	if link == "" {
-		return result, nil
+// collectNumbers returns the original text of each number in the JSON
+// document body, keyed by its JSON pointer.
+func collectNumbers(body []byte) map[string]json.Number {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if decoder.Decode(&document) != nil {
+		return nil
+	}
+
+	numbers := make(map[string]json.Number)
+	var walk func(pointer string, value interface{})
+	walk = func(pointer string, value interface{}) {
+		switch v := value.(type) {
+		case json.Number:
+			numbers[pointer] = v
+		case map[string]interface{}:
+			for key, child := range v {
+				key = strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+				walk(pointer+"/"+key, child)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walk(pointer+"/"+strconv.Itoa(i), child)
+			}
+		}
+	}
+	walk("", document)
+
+	return numbers
+}
+
+// Number returns the number at the JSON pointer, such as
+// "/PowerControl/0/PowerMetrics/IntervalInMin", exactly as the service sent
+// it. This lets conformance tools detect type deviations, like an integer
+// property sent as 1.0, that decoding into float64 hides. It requires a
+// client configured with UseNumber; false is returned otherwise or if there
+// is no number at the pointer.
+func (power *Power) Number(pointer string) (json.Number, bool) {
+	number, ok := power.numbers[pointer]
+	return number, ok
+}
+
+// GetOem unmarshals the raw Oem properties of the resource into target, a
+// pointer to a struct describing the vendor sections of interest, such as
+// struct{ Dell struct{ ... } }. If no Oem properties were reported, target
+// is left unchanged.
+func (power *Power) GetOem(target interface{}) error {
+	return decodeOem(power.Oem, target)
+}
+
+// decodeOem unmarshals the raw Oem properties into target, leaving it
+// unchanged if none were reported.
+func decodeOem(oem json.RawMessage, target interface{}) error {
+	if len(oem) == 0 || string(oem) == "null" {
+		return nil
+	}
+	return json.Unmarshal(oem, target)
+}
+
+// OemActions returns the vendor specific actions of the resource, keyed by
+// their name, such as "#Oem.Dell.ResetPowerBudget". The actions can be
+// invoked by POSTing to their Target with the client. Nil is returned if the
+// service reports none.
+func (power *Power) OemActions() (map[string]common.ActionTarget, error) {
+	var actions struct {
+		Oem map[string]common.ActionTarget
+	}
+	if err := decodeOem(power.actions, &actions); err != nil {
+		return nil, fmt.Errorf("decoding actions of %s: %w", power.ODataID, err)
+	}
+
+	return actions.Oem, nil
+}
+
+// PowerSupplyReset resets the power supply with the given MemberId, such as
+// with ForceRestartResetType. An empty reset type leaves the choice to the
+// service. If the service lists the reset types the action accepts, other
+// types are rejected without a request.
+func (power *Power) PowerSupplyReset(memberID string, resetType ResetType) error {
+	if power.powerSupplyResetTarget == "" {
+		return fmt.Errorf("PowerSupplyReset is not supported by %s", power.ODataID)
+	}
+	if power.Client == nil {
+		return fmt.Errorf("power resource %q has no client", power.ODataID)
+	}
+
+	// Make sure the requested reset type is supported
+	valid := resetType == "" || len(power.SupportedPowerSupplyResetTypes) == 0
+	for _, allowed := range power.SupportedPowerSupplyResetTypes {
+		if resetType == allowed {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("reset type '%s' is not supported by this power supply", resetType)
+	}
+
+	t := struct {
+		MemberID  string    `json:"MemberId"`
+		ResetType ResetType `json:",omitempty"`
+	}{
+		MemberID:  memberID,
+		ResetType: resetType,
 	}
 
-	links, err := common.GetCollection(c, link)
+	resp, err := power.Client.Post(power.powerSupplyResetTarget, t)
 	if err != nil {
-		fmt.Println("power.go ListReferencedPowers getcollection 有报错！")
-		return result, err
-	}else{
-		fmt.Println("power.go ListReferencedPowers getcollection 没有错！")
+		return common.ClassifyError(power.powerSupplyResetTarget, err)
 	}
+	resp.Body.Close()
 
-	collectionError := common.NewCollectionError()
-	for _, powerLink := range links.ItemLinks {
-		power, err := GetPower(c, powerLink)
-		if err != nil {
-			collectionError.Failures[powerLink] = err
-		} else {
-			result = append(result, power)
+	return nil
+}
+
+// Refresh reads the resource from the service again with its client and
+// updates the power controls, power supplies, voltages and redundancy groups
+// in place. The other properties, and anything resolved through them, are
+// kept. If the resource no longer exists, the returned error wraps the
+// *common.RedfishError reporting 404 Not Found.
+func (power *Power) Refresh() error {
+	uri := power.ODataID
+	if uri == "" {
+		return fmt.Errorf("power resource has no URI")
+	}
+	if power.Client == nil {
+		return fmt.Errorf("power resource %s has no client", uri)
+	}
+
+	updated, err := GetPower(power.Client, uri)
+	if err != nil {
+		var redfishErr *common.RedfishError
+		if errors.As(err, &redfishErr) && redfishErr.StatusCode() == http.StatusNotFound {
+			return fmt.Errorf("power resource %s no longer exists: %w", uri, err)
 		}
+		return err
 	}
 
-	if collectionError.Empty() {
-		return result, nil
+	power.ODataEtag = updated.ODataEtag
+	power.PowerControl = updated.PowerControl
+	power.PowerControlCount = updated.PowerControlCount
+	power.PowerSupplies = updated.PowerSupplies
+	power.PowerSuppliesCount = updated.PowerSuppliesCount
+	power.Redundancy = updated.Redundancy
+	power.RedundancyCount = updated.RedundancyCount
+	power.Voltages = updated.Voltages
+	power.VoltagesCount = updated.VoltagesCount
+	power.DecodeWarnings = updated.DecodeWarnings
+	power.etag = updated.etag
+	power.numbers = updated.numbers
+
+	return nil
+}
+
+// HasChanged checks whether the resource changed on the service since it was
+// read, using a conditional GET with its entity tag. If unchanged the service
+// answers 304 Not Modified and nothing is parsed. Otherwise the resource is
+// updated in place from the response. Without an entity tag the resource is
+// always re-read and reported as changed.
+func (power *Power) HasChanged(c common.Client) (bool, error) {
+	uri := power.ODataID
+	if uri == "" {
+		return false, fmt.Errorf("power resource has no URI")
 	}
 
-	return result, collectionError
+	var headers map[string]string
+	if power.etag != "" {
+		headers = map[string]string{"If-None-Match": power.etag}
+	}
+
+	resp, err := c.GetWithHeaders(uri, headers)
+	if err != nil {
+		var redfishErr *common.Error
+		if errors.As(err, &redfishErr) && redfishErr.HTTPReturnedStatusCode == http.StatusNotModified {
+			return false, nil
+		}
+		return false, common.ClassifyError(uri, err)
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return false, nil
+	}
+
+	updated, err := parsePower(c, uri, resp)
+	if err != nil {
+		return false, err
+	}
+	*power = *updated
+
+	return true, nil
 }
 
-// PowerControl is
-type PowerControl struct {
-	common.Entity
+// MergePower combines two partial views of the same Power resource, such as
+// from separate $select queries, into a new Power. Neither input is changed.
+//
+// Each property is taken from a if it is set there, and from b otherwise, so
+// a wins when both have a value. Arrays (PowerControl, PowerSupplies,
+// Redundancy and Voltages) are taken whole, along with their counts, rather
+// than merged element by element. Decode warnings from both are kept.
+func MergePower(a, b *Power) *Power {
+	if a == nil && b == nil {
+		return nil
+	}
+	if a == nil {
+		a = &Power{}
+	}
+	if b == nil {
+		b = &Power{}
+	}
 
-	// MemberID shall uniquely identify the member within the collection. For
-	// services supporting Redfish v1.6 or higher, this value shall be the
-	// zero-based array index.
-	MemberID string `json:"MemberId"`
-	// PhysicalContext shall be a description of the affected device(s) or region
-	// within the chassis to which this power control applies.
-	PhysicalContext common.PhysicalContext
-	// PowerAllocatedWatts shall represent the total power currently allocated
-	// to chassis resources.
-	PowerAllocatedWatts float64
-	// PowerAvailableWatts shall represent the amount of power capacity (in
-	// Watts) not already allocated and shall equal PowerCapacityWatts -
-	// PowerAllocatedWatts.
-	PowerAvailableWatts float64
-	// PowerCapacityWatts shall represent the total power capacity that is
-	// available for allocation to the chassis resources.
-	PowerCapacityWatts float64
-	// PowerConsumedWatts shall represent the actual power being consumed (in
-	// Watts) by the chassis.
-	PowerConsumedWatts float64
-	// PowerLimit shall contain power limit status and configuration information
-	// for this chassis.
-	PowerLimit PowerLimit
-	// PowerMetrics shall contain power metrics for power readings (interval,
-	// minimum/maximum/average power consumption) for the chassis.
-	PowerMetrics PowerMetric
-	// PowerRequestedWatts shall represent the
-	// amount of power (in Watts) that the chassis resource is currently
-	// requesting be budgeted to it for future use.
-	PowerRequestedWatts float64
-	// Status shall contain any status or health properties
-	// of the resource.
-	Status common.Status
+	result := *a
+	pick := func(current *string, other string) {
+		if *current == "" {
+			*current = other
+		}
+	}
+	pick(&result.ODataID, b.ODataID)
+	pick(&result.ID, b.ID)
+	pick(&result.Name, b.Name)
+	pick(&result.ODataContext, b.ODataContext)
+	pick(&result.ODataEtag, b.ODataEtag)
+	pick(&result.ODataType, b.ODataType)
+	pick(&result.Description, b.Description)
+	pick(&result.logService, b.logService)
+	pick(&result.logServices, b.logServices)
+	pick(&result.metrics, b.metrics)
+	pick(&result.etag, b.etag)
+	if result.IndicatorLED == "" {
+		result.IndicatorLED = b.IndicatorLED
+	}
+	if result.Client == nil {
+		result.Client = b.Client
+	}
+	if len(result.Oem) == 0 {
+		result.Oem = b.Oem
+	}
+	if len(result.allowedMethods) == 0 {
+		result.allowedMethods = b.allowedMethods
+	}
+
+	if len(result.PowerControl) == 0 {
+		result.PowerControl, result.PowerControlCount = b.PowerControl, b.PowerControlCount
+	}
+	if len(result.PowerSupplies) == 0 {
+		result.PowerSupplies, result.PowerSuppliesCount = b.PowerSupplies, b.PowerSuppliesCount
+	}
+	if len(result.Redundancy) == 0 {
+		result.Redundancy, result.RedundancyCount = b.Redundancy, b.RedundancyCount
+	}
+	if len(result.Voltages) == 0 {
+		result.Voltages, result.VoltagesCount = b.Voltages, b.VoltagesCount
+	}
+
+	result.DecodeWarnings = append(append([]common.DecodeWarning(nil), a.DecodeWarnings...), b.DecodeWarnings...)
+	if len(a.numbers) > 0 || len(b.numbers) > 0 {
+		result.numbers = make(map[string]json.Number, len(a.numbers)+len(b.numbers))
+		for pointer, number := range b.numbers {
+			result.numbers[pointer] = number
+		}
+		for pointer, number := range a.numbers {
+			result.numbers[pointer] = number
+		}
+	}
+
+	return &result
+}
+
+// HistogramByLoad buckets the present power supplies across powers by their
+// output load. The returned map is keyed by bucket index, where bucket n holds
+// supplies with a load of at least n*bucketPct and less than (n+1)*bucketPct
+// percent, and contains the number of supplies in each bucket. Supplies that
+// do not report a capacity are excluded.
+func HistogramByLoad(powers []*Power, bucketPct float64) map[int]int {
+	result := make(map[int]int)
+	if bucketPct <= 0 {
+		return result
+	}
+
+	for _, power := range powers {
+		if power == nil {
+			continue
+		}
+		for i := range power.PowerSupplies {
+			supply := &power.PowerSupplies[i]
+			if !supply.IsPresent() {
+				continue
+			}
+			if load, ok := supply.LoadPercent(); ok {
+				result[int(math.Floor(load/bucketPct))]++
+			}
+		}
+	}
+
+	return result
+}
+
+// DiscoverPower locates and retrieves the Power resource for the chassis with
+// the given ID, walking from the service root through the Chassis collection.
+// The returned error identifies the step of the walk that failed.
+func DiscoverPower(c common.Client, chassisID string) (*Power, error) {
+	var root struct {
+		Chassis common.Link
+	}
+	err := getJSON(c, common.DefaultServiceRoot, &root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read service root: %w", err)
+	}
+	if root.Chassis == "" {
+		return nil, fmt.Errorf("service root does not link to a chassis collection")
+	}
+
+	links, err := common.GetCollection(c, string(root.Chassis))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chassis collection: %w", err)
+	}
+
+	var chassisLink string
+	for _, link := range links.ItemLinks {
+		if path.Base(link) == chassisID {
+			chassisLink = link
+			break
+		}
+	}
+	if chassisLink == "" {
+		return nil, fmt.Errorf("chassis %q not found in %s", chassisID, root.Chassis)
+	}
+
+	var chassis struct {
+		Power common.Link
+	}
+	err = getJSON(c, chassisLink, &chassis)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chassis %q: %w", chassisID, err)
+	}
+	if chassis.Power == "" {
+		return nil, fmt.Errorf("chassis %q does not link to a power resource", chassisID)
+	}
+
+	power, err := GetPower(c, string(chassis.Power))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read power for chassis %q: %w", chassisID, err)
+	}
+
+	return power, nil
+}
+
+// getJSON retrieves uri and decodes the response body into target.
+func getJSON(c common.Client, uri string, target interface{}) error {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// VoltagesByContext returns the voltage sensors of this resource in the
+// given physical context, such as common.CPUPhysicalContext. Vendor
+// spellings of the context are accepted as in NormalizePhysicalContext.
+func (power *Power) VoltagesByContext(physicalContext common.PhysicalContext) []Voltage {
+	physicalContext = NormalizePhysicalContext(string(physicalContext))
+
+	var result []Voltage
+	for i := range power.Voltages {
+		if power.Voltages[i].PhysicalContext == physicalContext {
+			result = append(result, power.Voltages[i])
+		}
+	}
+	return result
+}
+
+// PowerControlByContext returns the power controls of this resource in the
+// given physical context, matched as in VoltagesByContext.
+func (power *Power) PowerControlByContext(physicalContext common.PhysicalContext) []PowerControl {
+	physicalContext = NormalizePhysicalContext(string(physicalContext))
+
+	var result []PowerControl
+	for i := range power.PowerControl {
+		if power.PowerControl[i].PhysicalContext == physicalContext {
+			result = append(result, power.PowerControl[i])
+		}
+	}
+	return result
+}
+
+// PhysicalContexts returns the distinct physical contexts of the power
+// controls and voltage sensors of this resource, in sorted order.
+func (power *Power) PhysicalContexts() []common.PhysicalContext {
+	seen := make(map[common.PhysicalContext]bool)
+	for i := range power.PowerControl {
+		seen[power.PowerControl[i].PhysicalContext] = true
+	}
+	for i := range power.Voltages {
+		seen[power.Voltages[i].PhysicalContext] = true
+	}
+	delete(seen, "")
+
+	result := make([]common.PhysicalContext, 0, len(seen))
+	for physicalContext := range seen {
+		result = append(result, physicalContext)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
+
+// FlatReadings returns the numeric readings of this resource keyed by stable,
+// fully qualified names such as "powercontrol.0.consumed_watts",
+// "powersupply.PSU1.output_watts" and "voltage.VRM1.reading_volts". Members
+// are identified by MemberID, or by array index if no MemberID is reported.
+// Only readings the service reported, or that are set to a value other than
+// 0, are included, so a missing key means the reading is not available
+// rather than 0.
+func (power *Power) FlatReadings() map[string]float64 {
+	result := make(map[string]float64)
+	add := func(kind, id string, index int, name string, value float64, reported bool) {
+		if !reported && value == 0 {
+			return
+		}
+		if id == "" {
+			id = strconv.Itoa(index)
+		}
+		result[kind+"."+id+"."+name] = value
+	}
+
+	for i := range power.PowerControl {
+		control := &power.PowerControl[i]
+		reported := decodeReportedProperties(control.rawData)
+		add("powercontrol", control.MemberID, i, "consumed_watts", control.PowerConsumedWatts, control.consumedReported)
+		add("powercontrol", control.MemberID, i, "capacity_watts", control.PowerCapacityWatts, control.capacityReported)
+		add("powercontrol", control.MemberID, i, "allocated_watts", control.PowerAllocatedWatts, reported.has("PowerAllocatedWatts"))
+		add("powercontrol", control.MemberID, i, "available_watts", control.PowerAvailableWatts, reported.has("PowerAvailableWatts"))
+		add("powercontrol", control.MemberID, i, "requested_watts", control.PowerRequestedWatts, reported.has("PowerRequestedWatts"))
+		add("powercontrol", control.MemberID, i, "limit_watts", control.PowerLimit.LimitInWatts, reported.has("PowerLimit", "LimitInWatts"))
+		add("powercontrol", control.MemberID, i, "average_consumed_watts", control.PowerMetrics.AverageConsumedWatts, reported.has("PowerMetrics", "AverageConsumedWatts"))
+		add("powercontrol", control.MemberID, i, "min_consumed_watts", control.PowerMetrics.MinConsumedWatts, reported.has("PowerMetrics", "MinConsumedWatts"))
+		add("powercontrol", control.MemberID, i, "max_consumed_watts", control.PowerMetrics.MaxConsumedWatts, reported.has("PowerMetrics", "MaxConsumedWatts"))
+		add("powercontrol", control.MemberID, i, "interval_min", control.PowerMetrics.IntervalInMin, reported.has("PowerMetrics", "IntervalInMin"))
+	}
+
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		reported := decodeReportedProperties(supply.rawData)
+		add("powersupply", supply.MemberID, i, "capacity_watts", supply.PowerCapacityWatts, reported.has("PowerCapacityWatts"))
+		add("powersupply", supply.MemberID, i, "input_watts", supply.PowerInputWatts, reported.has("PowerInputWatts"))
+		add("powersupply", supply.MemberID, i, "output_watts", supply.PowerOutputWatts, reported.has("PowerOutputWatts"))
+		add("powersupply", supply.MemberID, i, "last_output_watts", supply.LastPowerOutputWatts, reported.has("LastPowerOutputWatts"))
+		add("powersupply", supply.MemberID, i, "line_input_voltage", supply.LineInputVoltage, reported.has("LineInputVoltage"))
+		add("powersupply", supply.MemberID, i, "efficiency_percent", supply.EfficiencyPercent, reported.has("EfficiencyPercent"))
+	}
+
+	for i := range power.Voltages {
+		voltage := &power.Voltages[i]
+		add("voltage", voltage.MemberID, i, "reading_volts", voltage.ReadingVolts, voltage.readingReported)
+	}
+
+	return result
+}
+
+// reportedProperties holds the top level properties of the raw JSON of a
+// member, to tell which properties the service reported.
+type reportedProperties map[string]json.RawMessage
+
+// decodeReportedProperties decodes the properties of raw, which is empty for
+// a member that was not decoded from the service.
+func decodeReportedProperties(raw []byte) reportedProperties {
+	var properties reportedProperties
+	if len(raw) == 0 || json.Unmarshal(raw, &properties) != nil {
+		return nil
+	}
+
+	return properties
+}
+
+// has reports whether the property at the path, such as "PowerLimit",
+// "LimitInWatts", was reported with a value other than null.
+func (properties reportedProperties) has(path ...string) bool {
+	current := properties
+	for i, name := range path {
+		value := current[name]
+		if len(value) == 0 || string(value) == "null" {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		current = nil
+		if json.Unmarshal(value, &current) != nil {
+			return false
+		}
+	}
+
+	return false
+}
+
+// PowerEvent is a power related log entry.
+type PowerEvent struct {
+	// Created is when the entry was recorded, or the zero time if the entry
+	// has no parseable timestamp.
+	Created time.Time
+	// EntryCode is the entry code of the log entry, if any.
+	EntryCode LogEntryCode
+	// Message is the human readable message of the entry.
+	Message string
+	// MessageID is the message registry key of the entry, if any.
+	MessageID string
+	// SensorType is the sensor type of the entry, if any.
+	SensorType SensorType
+	// Severity is the severity of the entry.
+	Severity EventSeverity
+}
+
+// RecentPowerEvents follows any log service links of this resource and
+// returns the power related entries, newest first. Services that do not link
+// a log to their Power resource return an empty slice.
+func (power *Power) RecentPowerEvents(c common.Client) ([]PowerEvent, error) {
+	result := []PowerEvent{}
+
+	var services []*LogService
+	if power.logService != "" {
+		service, err := GetLogService(c, power.logService)
+		if err != nil {
+			return result, err
+		}
+		services = append(services, service)
+	}
+	if power.logServices != "" {
+		list, err := ListReferencedLogServices(c, power.logServices)
+		if err != nil {
+			return result, err
+		}
+		services = append(services, list...)
+	}
+
+	for _, service := range services {
+		entries, err := service.Entries()
+		if err != nil {
+			return result, err
+		}
+
+		for _, entry := range entries {
+			if !isPowerLogEntry(entry) {
+				continue
+			}
+			created, _ := time.Parse(time.RFC3339, entry.Created)
+			result = append(result, PowerEvent{
+				Created:    created,
+				EntryCode:  entry.EntryCode,
+				Message:    entry.Message,
+				MessageID:  entry.MessageID,
+				SensorType: entry.SensorType,
+				Severity:   entry.Severity,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Created.After(result[j].Created) })
+
+	return result, nil
+}
+
+// isPowerLogEntry reports whether a log entry relates to power.
+func isPowerLogEntry(entry *LogEntry) bool {
+	switch entry.SensorType {
+	case PowerSupplyConverterSensorType, PowerUnitSensorType:
+		return true
+	}
+
+	switch entry.EntryCode {
+	case TransitionToPowerOffLogEntryCode, TransitionToPowerSaveLogEntryCode:
+		return true
+	}
+
+	// Registry messages such as "PowerSupplyFailed" or "ServerPoweredOn"
+	messageID := strings.ToLower(entry.MessageID)
+	return strings.Contains(messageID, "power")
+}
+
+// TopConsumer returns the power control domain with the highest
+// PowerConsumedWatts. False is returned if no domain reports any consumption.
+func (power *Power) TopConsumer() (*PowerControl, bool) {
+	var top *PowerControl
+	for i := range power.PowerControl {
+		control := &power.PowerControl[i]
+		if control.PowerConsumedWatts > 0 &&
+			(top == nil || control.PowerConsumedWatts > top.PowerConsumedWatts) {
+			top = control
+		}
+	}
+
+	return top, top != nil
+}
+
+// maxConcurrentSensorFetches bounds the number of Sensor resources fetched
+// at the same time when resolving voltage references.
+const maxConcurrentSensorFetches = 8
+
+// sensorReference returns the URI of the Sensor resource a voltage entry
+// refers to, or an empty string if the entry is inline.
+func (voltage *Voltage) sensorReference() string {
+	if voltage.DataSourceURI != "" {
+		return voltage.DataSourceURI
+	}
+	// A bare link carries nothing but the URI of the sensor, while inline
+	// entries have a MemberID and usually a fragment of the Power resource.
+	if voltage.ODataID != "" && !strings.Contains(voltage.ODataID, "#") && voltage.MemberID == "" {
+		return voltage.ODataID
+	}
+
+	return ""
+}
+
+// ResolveAllVoltages fetches the Sensor resources referenced by the Voltages
+// of this resource and populates each Voltage from its sensor. Voltages that
+// are already inline are left as is. Sensors are fetched concurrently, with
+// at most maxConcurrentSensorFetches requests in flight; all voltages that
+// could be resolved are populated even if others fail. A sensor reporting a
+// ReadingType or ReadingUnits other than volts is reported as a failure and
+// its voltage is left as is.
+func (power *Power) ResolveAllVoltages(c common.Client) error {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, maxConcurrentSensorFetches)
+	)
+	collectionError := common.NewCollectionError()
+
+	for i := range power.Voltages {
+		uri := power.Voltages[i].sensorReference()
+		if uri == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(voltage *Voltage, uri string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var sensor Sensor
+			if err := getJSON(c, uri, &sensor); err != nil {
+				mu.Lock()
+				collectionError.Failures[uri] = err
+				mu.Unlock()
+				return
+			}
+			if err := voltage.fromSensor(c, uri, &sensor); err != nil {
+				mu.Lock()
+				collectionError.Failures[uri] = err
+				mu.Unlock()
+			}
+		}(&power.Voltages[i], uri)
+	}
+	wg.Wait()
+
+	if collectionError.Empty() {
+		return nil
+	}
+
+	return collectionError
+}
+
+// fromSensor populates the voltage from a Sensor resource, keeping the
+// MemberID of the original entry. An error is returned, and the voltage left
+// unchanged, if the sensor does not measure voltage.
+func (voltage *Voltage) fromSensor(c common.Client, uri string, sensor *Sensor) error {
+	if sensor.ReadingType != "" && sensor.ReadingType != VoltageReadingType {
+		return fmt.Errorf("sensor %s measures %s, not voltage", uri, sensor.ReadingType)
+	}
+	if sensor.ReadingUnits != "" && sensor.ReadingUnits != "V" {
+		return fmt.Errorf("sensor %s reads in %s, not volts", uri, sensor.ReadingUnits)
+	}
+
+	memberID := voltage.MemberID
+	*voltage = Voltage{
+		Entity:                    sensor.Entity,
+		DataSourceURI:             uri,
+		LowerThresholdCritical:    sensor.Thresholds.LowerCritical.Reading,
+		LowerThresholdFatal:       sensor.Thresholds.LowerFatal.Reading,
+		LowerThresholdNonCritical: sensor.Thresholds.LowerCaution.Reading,
+		MaxReadingRange:           sensor.ReadingRangeMax,
+		MemberID:                  memberID,
+		MinReadingRange:           sensor.ReadingRangeMin,
+		PhysicalContext:           sensor.PhysicalContext,
+		ReadingVolts:              sensor.Reading,
+		SensorNumber:              sensor.SensorNumber,
+		Status:                    sensor.Status,
+		UpperThresholdCritical:    sensor.Thresholds.UpperCritical.Reading,
+		UpperThresholdFatal:       sensor.Thresholds.UpperFatal.Reading,
+		UpperThresholdNonCritical: sensor.Thresholds.UpperCaution.Reading,
+	}
+	voltage.reportedThresholds = reportedThresholds(
+		sensor.Thresholds.LowerCaution.reading(), sensor.Thresholds.LowerCritical.reading(), sensor.Thresholds.LowerFatal.reading(),
+		sensor.Thresholds.UpperCaution.reading(), sensor.Thresholds.UpperCritical.reading(), sensor.Thresholds.UpperFatal.reading())
+	voltage.readingReported = true
+	if voltage.ODataID == "" {
+		voltage.ODataID = uri
+	}
+	voltage.SetClient(c)
+
+	return nil
+}
+
+// FailedPresentSupplies returns the power supplies that are installed but
+// failed, as reported by PowerSupply.IsFailedPresent.
+func (power *Power) FailedPresentSupplies() []PowerSupply {
+	var result []PowerSupply
+	for i := range power.PowerSupplies {
+		if power.PowerSupplies[i].IsFailedPresent() {
+			result = append(result, power.PowerSupplies[i])
+		}
+	}
+
+	return result
+}
+
+// PartialUpdateError is returned when changes to several members of a
+// resource, such as the indicator LEDs of its power supplies, could not all
+// be applied.
+type PartialUpdateError struct {
+	// Updated is the MemberIDs of the members that were changed.
+	Updated []string
+	// Failures holds the error for each MemberID that could not be changed.
+	Failures map[string]error
+}
+
+// Error lists the members that could not be changed.
+func (e *PartialUpdateError) Error() string {
+	memberIDs := make([]string, 0, len(e.Failures))
+	for memberID := range e.Failures {
+		memberIDs = append(memberIDs, memberID)
+	}
+	sort.Strings(memberIDs)
+
+	failures := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		failures = append(failures, fmt.Sprintf("%s: %v", memberID, e.Failures[memberID]))
+	}
+
+	return fmt.Sprintf("updated %d of %d members, failed %s",
+		len(e.Updated), len(e.Updated)+len(e.Failures), strings.Join(failures, "; "))
+}
+
+// SetSupplyIndicatorLEDs sets the indicator LEDs of the power supplies keyed
+// by MemberID, such as to blink the LEDs of the supplies to be replaced. The
+// supplies embedded in this resource are changed together with a single
+// PATCH of its PowerSupplies array, matched by position, while supplies with
+// their own URI are changed one at a time. Nothing is sent if a MemberID is
+// not in PowerSupplies or its LED cannot be controlled, as reported by
+// PowerSupply.SupportsIndicatorLED. If only some of the supplies could be
+// changed, a *PartialUpdateError is returned.
+func (power *Power) SetSupplyIndicatorLEDs(leds map[string]common.IndicatorLED) error {
+	supplies := make(map[string]*PowerSupply)
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if _, ok := leds[supply.MemberID]; ok {
+			supplies[supply.MemberID] = supply
+		}
+	}
+
+	memberIDs := make([]string, 0, len(leds))
+	for memberID := range leds {
+		memberIDs = append(memberIDs, memberID)
+	}
+	sort.Strings(memberIDs)
+
+	for _, memberID := range memberIDs {
+		supply, ok := supplies[memberID]
+		if !ok {
+			return fmt.Errorf("power supply %q not found in %s", memberID, power.ODataID)
+		}
+		if !supply.SupportsIndicatorLED() {
+			return fmt.Errorf("power supply %q indicator LED: %w", memberID, common.ErrUpdateNotSupported)
+		}
+	}
+
+	if power.Client == nil {
+		return fmt.Errorf("power resource %q has no client", power.ODataID)
+	}
+
+	result := &PartialUpdateError{Failures: make(map[string]error)}
+
+	// Supplies embedded in this resource are PATCHed through its array, in
+	// which empty objects leave the other members unchanged
+	var embedded []string
+	changes := make(map[int]interface{})
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		led, ok := leds[supply.MemberID]
+		if !ok || !power.embeds(supply.ODataID) {
+			continue
+		}
+		index := i
+		if fragment := strings.SplitN(supply.ODataID, "#/PowerSupplies/", 2); len(fragment) == 2 {
+			if n, err := strconv.Atoi(fragment[1]); err == nil && n >= 0 {
+				index = n
+			}
+		}
+		changes[index] = map[string]interface{}{"IndicatorLED": led}
+		embedded = append(embedded, supply.MemberID)
+	}
+
+	if len(embedded) > 0 {
+		var headers map[string]string
+		if power.etag != "" {
+			headers = map[string]string{"If-Match": power.etag}
+		}
+		resp, err := power.Client.PatchWithHeaders(power.ODataID, map[string]interface{}{"PowerSupplies": arrayPatch(changes)}, headers)
+		if err == nil {
+			resp.Body.Close()
+		}
+		for _, memberID := range embedded {
+			if err != nil {
+				result.Failures[memberID] = common.ClassifyError(power.ODataID, err)
+				continue
+			}
+			supplies[memberID].IndicatorLED = leds[memberID]
+			result.Updated = append(result.Updated, memberID)
+		}
+	}
+
+	for _, memberID := range memberIDs {
+		supply := supplies[memberID]
+		if power.embeds(supply.ODataID) {
+			continue
+		}
+		if err := supply.SetIndicator(leds[memberID]); err != nil {
+			result.Failures[memberID] = err
+			continue
+		}
+		result.Updated = append(result.Updated, memberID)
+	}
+
+	if len(result.Failures) == 0 {
+		return nil
+	}
+
+	sort.Strings(result.Updated)
+	return result
+}
+
+// embeds reports whether the member with the given ODataID is embedded in
+// this resource rather than having a URI of its own.
+func (power *Power) embeds(odataID string) bool {
+	return odataID == "" || strings.HasPrefix(odataID, power.ODataID+"#")
+}
+
+// FetchMetrics retrieves the PowerMetrics resource linked from this resource,
+// which carries richer aggregated data than the PowerMetrics embedded in each
+// PowerControl. Nil is returned if the service does not link to one.
+func (power *Power) FetchMetrics(c common.Client) (*PowerMetrics, error) {
+	if power.metrics == "" {
+		return nil, nil
+	}
+
+	return GetPowerMetrics(c, power.metrics)
+}
+
+// BudgetNode is the power budget of a chassis, with the budgets of the
+// chassis it contains.
+type BudgetNode struct {
+	// ChassisURI is the URI of the chassis.
+	ChassisURI string
+	// Name is the name of the chassis.
+	Name string
+	// AllocatedWatts is the PowerAllocatedWatts of the chassis power domain.
+	AllocatedWatts float64
+	// AvailableWatts is the PowerAvailableWatts of the chassis power domain.
+	AvailableWatts float64
+	// Children are the budgets of the contained chassis.
+	Children []*BudgetNode
+}
+
+// budgetChassis holds the Chassis properties needed to build a BudgetNode.
+type budgetChassis struct {
+	common.Entity
+	Power common.Link
+	Links struct {
+		Contains common.Links
+	}
+}
+
+// chassisURI returns the URI of the chassis this resource belongs to,
+// derived from the URI of this resource, which must be the Power resource of
+// a member of the chassis collection, such as /redfish/v1/Chassis/1/Power.
+func (power *Power) chassisURI() (string, error) {
+	uri := strings.TrimSuffix(strings.SplitN(power.ODataID, "#", 2)[0], "/")
+	if !strings.HasSuffix(uri, "/Power") {
+		return "", fmt.Errorf("power resource %q is not a Power resource of a chassis", power.ODataID)
+	}
+
+	chassisURI := strings.TrimSuffix(uri, "/Power")
+	if path.Base(path.Dir(chassisURI)) != "Chassis" || path.Base(chassisURI) == "Chassis" {
+		return "", fmt.Errorf("power resource %q is not a Power resource of a chassis", power.ODataID)
+	}
+
+	return chassisURI, nil
+}
+
+// chassisClient returns c, or the client of this resource if c is nil, to
+// read the chassis with.
+func (power *Power) chassisClient(c common.Client) (common.Client, error) {
+	if c == nil {
+		c = power.Client
+	}
+	if c == nil {
+		return nil, fmt.Errorf("power resource %q has no client", power.ODataID)
+	}
+
+	return c, nil
+}
+
+// Chassis gets the chassis this resource belongs to. Its URI is derived
+// from the URI of this resource, which must be the Power resource of a
+// member of the chassis collection, such as /redfish/v1/Chassis/1/Power.
+func (power *Power) Chassis() (*Chassis, error) {
+	uri, err := power.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+	c, err := power.chassisClient(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetChassis(c, uri)
+}
+
+// Thermal gets the Thermal resource of the chassis this resource belongs to,
+// for correlating power draw with cooling. The chassis is found as described
+// for Chassis and only its Thermal link is read. An error is returned if the
+// chassis does not link to a Thermal resource.
+func (power *Power) Thermal() (*Thermal, error) {
+	uri, err := power.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+	c, err := power.chassisClient(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var chassis struct {
+		Thermal common.Link
+	}
+	err = getJSON(c, uri, &chassis)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chassis %s: %w", uri, err)
+	}
+	if chassis.Thermal == "" {
+		return nil, fmt.Errorf("chassis %s has no Thermal resource", uri)
+	}
+
+	return GetThermal(c, string(chassis.Thermal))
+}
+
+// ChassisInfo returns the identifying strings of the chassis this resource
+// belongs to, for reports that list power data by asset. The chassis is
+// found as described for Chassis and read with c, or the client of this
+// resource if c is nil.
+func (power *Power) ChassisInfo(c common.Client) (sku, assetTag, serial string, err error) {
+	uri, err := power.chassisURI()
+	if err != nil {
+		return "", "", "", err
+	}
+	c, err = power.chassisClient(c)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var chassis struct {
+		AssetTag     string
+		SKU          string
+		SerialNumber string
+	}
+	err = getJSON(c, uri, &chassis)
+	if err != nil {
+		return "", "", "", fmt.Errorf("unable to read chassis %s: %w", uri, err)
+	}
+
+	return chassis.SKU, chassis.AssetTag, chassis.SerialNumber, nil
+}
+
+// BudgetTree walks down from the chassis of this resource through the
+// chassis it contains, collecting the allocated and available watts of each
+// chassis power domain into a tree. This shows where power budget is
+// committed across an enclosure and its sleds. A chassis reached a second
+// time, as a service with cyclic containment links would cause, is skipped.
+// The chassis of this resource is found as described for Chassis, and the
+// chassis are read with c, or the client of this resource if c is nil.
+func (power *Power) BudgetTree(c common.Client) (*BudgetNode, error) {
+	uri, err := power.chassisURI()
+	if err != nil {
+		return nil, err
+	}
+	c, err = power.chassisClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgetTree(c, uri, power, make(map[string]bool))
+}
+
+// budgetTree builds the BudgetNode of the chassis at uri. If known is not
+// nil it is used as the Power resource of the chassis rather than fetching
+// it.
+func budgetTree(c common.Client, uri string, known *Power, visited map[string]bool) (*BudgetNode, error) {
+	visited[uri] = true
+
+	var chassis budgetChassis
+	err := getJSON(c, uri, &chassis)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chassis %s: %w", uri, err)
+	}
+
+	node := &BudgetNode{ChassisURI: uri, Name: chassis.Name}
+
+	power := known
+	if power == nil && chassis.Power != "" {
+		power, err = getParentPower(c, string(chassis.Power))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read power of chassis %s: %w", uri, err)
+		}
+	}
+	if control := power.chassisControl(); control != nil {
+		node.AllocatedWatts = control.PowerAllocatedWatts
+		node.AvailableWatts = control.PowerAvailableWatts
+	}
+
+	for _, child := range chassis.Links.Contains.ToStrings() {
+		if visited[child] {
+			continue
+		}
+		childNode, err := budgetTree(c, child, nil, visited)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+
+	return node, nil
+}
+
+// chassisControl returns the PowerControl describing the whole chassis,
+// which by convention is the first, or nil if there is none.
+func (power *Power) chassisControl() *PowerControl {
+	if power == nil || len(power.PowerControl) == 0 {
+		return nil
+	}
+
+	return &power.PowerControl[0]
+}
+
+// consumedWatts returns the current consumption of the chassis, taken from
+// the first PowerControl or, if it reports none, the summed output of the
+// power supplies.
+func (power *Power) consumedWatts() float64 {
+	if len(power.PowerControl) > 0 && power.PowerControl[0].PowerConsumedWatts > 0 {
+		return power.PowerControl[0].PowerConsumedWatts
+	}
+
+	var total float64
+	for i := range power.PowerSupplies {
+		total += power.PowerSupplies[i].PowerOutputWatts
+	}
+
+	return total
+}
+
+// RedundancyLossMatrix reports, for each present power supply keyed by
+// MemberID, whether the chassis would keep running if that supply were lost.
+// A loss is survivable if the capacity of the remaining working supplies
+// covers the current consumption. If the power supplies are configured as
+// not redundant, or as spares that are not taken over automatically, no
+// loss is considered survivable.
+func (power *Power) RedundancyLossMatrix() map[string]bool {
+	result := make(map[string]bool)
+
+	automatic := true
+	if len(power.Redundancy) > 0 {
+		switch power.Redundancy[0].Mode {
+		case NotRedundantRedundancyMode, SparingRedundancyMode:
+			automatic = false
+		}
+	}
+
+	consumed := power.consumedWatts()
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if !supply.IsPresent() {
+			continue
+		}
+
+		var remaining float64
+		for j := range power.PowerSupplies {
+			other := &power.PowerSupplies[j]
+			if j != i && other.IsPresent() && !other.IsFailedPresent() {
+				remaining += other.PowerCapacityWatts
+			}
+		}
+		result[supply.MemberID] = automatic && remaining >= consumed
+	}
+
+	return result
+}
+
+// ModelCatalog provides the rated capacity of power supply models, such as
+// from an inventory database. No catalog is built in; callers supply their
+// own.
+type ModelCatalog interface {
+	// RatedWatts returns the rated capacity of a model, or false if the
+	// model is not known.
+	RatedWatts(model string) (float64, bool)
+}
+
+// catalogToleranceWatts is how far a reported capacity may be from the
+// catalog rating before it is reported as a mismatch.
+const catalogToleranceWatts = 0.5
+
+// ValidateAgainstCatalog compares the PowerCapacityWatts of each present power
+// supply with the rating of its Model in cat, and returns a description of
+// each mismatch. Supplies whose model is not in the catalog are skipped.
+func (power *Power) ValidateAgainstCatalog(cat ModelCatalog) []string {
+	var mismatches []string
+	if cat == nil {
+		return mismatches
+	}
+
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if !supply.IsPresent() || supply.Model == "" {
+			continue
+		}
+
+		rated, ok := cat.RatedWatts(supply.Model)
+		if !ok {
+			continue
+		}
+		if math.Abs(supply.PowerCapacityWatts-rated) > catalogToleranceWatts {
+			mismatches = append(mismatches, fmt.Sprintf("power supply %s (%s) reports %g W, model is rated %g W",
+				supply.MemberID, supply.Model, supply.PowerCapacityWatts, rated))
+		}
+	}
+
+	return mismatches
+}
+
+// RedundancyShortfall returns, for each redundancy group keyed by Name (or
+// MemberID if unnamed), how many more working members it needs to reach
+// MinNumNeeded, or 0 if it has enough. A member is working if it is present
+// and not failed. Groups that do not list their members are assumed to cover
+// all power supplies.
+func (power *Power) RedundancyShortfall() map[string]int {
+	result := make(map[string]int)
+	for i := range power.Redundancy {
+		redundancy := &power.Redundancy[i]
+		working := power.onlineRedundancyMembers(redundancy)
+
+		name := redundancy.Name
+		if name == "" {
+			name = redundancy.MemberID
+		}
+		result[name] = 0
+		if working < redundancy.MinNumNeeded {
+			result[name] = redundancy.MinNumNeeded - working
+		}
+	}
+
+	return result
+}
+
+// onlineRedundancyMembers counts the power supplies of the redundancy group
+// that are present and not failed. Groups that do not list their members are
+// assumed to cover all power supplies.
+func (power *Power) onlineRedundancyMembers(redundancy *Redundancy) int {
+	members := make(map[string]bool)
+	for _, link := range redundancy.redundancySet {
+		members[link] = true
+	}
+
+	var working int
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if len(members) > 0 && !members[supply.ODataID] {
+			continue
+		}
+		if supply.IsPresent() && !supply.IsFailedPresent() {
+			working++
+		}
+	}
+	return working
+}
+
+// isNominal reports whether the voltage reading is plausible and its sensor
+// reports no health problem.
+func (voltage *Voltage) isNominal() bool {
+	health := voltage.Status.Health
+	return voltage.IsReadingPlausible() && (health == "" || health == common.OKHealth)
+}
+
+// String returns a one line description of the voltage for logging, such as
+// "Voltage[0] VRM1 1.8V, OK".
+func (voltage *Voltage) String() string {
+	buf := make([]byte, 0, 48)
+	buf = append(buf, "Voltage["...)
+	buf = append(buf, voltage.MemberID...)
+	buf = append(buf, "] "...)
+	if voltage.Name != "" {
+		buf = append(buf, voltage.Name...)
+		buf = append(buf, ' ')
+	}
+	buf = strconv.AppendFloat(buf, voltage.ReadingVolts, 'f', -1, 64)
+	buf = append(buf, "V, "...)
+	buf = appendStatus(buf, voltage.Status)
+	return string(buf)
+}
+
+// Summary returns a one line description of this resource for logging, in
+// the form:
+//
+//	Power: 3/4 PSUs present, 1240W/2000W (62%), redundancy OK, 12 voltages nominal
+//
+// Readings the service does not report are shown as unknown, and a
+// redundancy shortfall or off-nominal voltages are counted.
+func (power *Power) Summary() string {
+	buf := make([]byte, 0, 96)
+
+	var present int
+	for i := range power.PowerSupplies {
+		if power.PowerSupplies[i].IsPresent() {
+			present++
+		}
+	}
+	buf = append(buf, "Power: "...)
+	buf = strconv.AppendInt(buf, int64(present), 10)
+	buf = append(buf, '/')
+	buf = strconv.AppendInt(buf, int64(len(power.PowerSupplies)), 10)
+	buf = append(buf, " PSUs present, "...)
+
+	control := power.chassisControl()
+	consumed, capacity := control.ConsumedWatts(), control.CapacityWatts()
+	switch {
+	case !consumed.Present:
+		buf = append(buf, "consumption unknown"...)
+	case !capacity.Present:
+		buf = strconv.AppendFloat(buf, consumed.Value, 'f', 0, 64)
+		buf = append(buf, 'W')
+	default:
+		buf = strconv.AppendFloat(buf, consumed.Value, 'f', 0, 64)
+		buf = append(buf, "W/"...)
+		buf = strconv.AppendFloat(buf, capacity.Value, 'f', 0, 64)
+		buf = append(buf, 'W')
+		if pct, ok := consumed.Percent(capacity); ok {
+			buf = append(buf, " ("...)
+			buf = strconv.AppendFloat(buf, pct, 'f', 0, 64)
+			buf = append(buf, "%)"...)
+		}
+	}
+
+	var short int
+	for _, missing := range power.RedundancyShortfall() {
+		short += missing
+	}
+	switch {
+	case len(power.Redundancy) == 0:
+		buf = append(buf, ", no redundancy"...)
+	case short > 0:
+		buf = append(buf, ", redundancy short "...)
+		buf = strconv.AppendInt(buf, int64(short), 10)
+	default:
+		buf = append(buf, ", redundancy OK"...)
+	}
+
+	var nominal int
+	for i := range power.Voltages {
+		if power.Voltages[i].isNominal() {
+			nominal++
+		}
+	}
+	buf = append(buf, ", "...)
+	if nominal != len(power.Voltages) {
+		buf = strconv.AppendInt(buf, int64(nominal), 10)
+		buf = append(buf, '/')
+	}
+	buf = strconv.AppendInt(buf, int64(len(power.Voltages)), 10)
+	buf = append(buf, " voltages nominal"...)
+
+	return string(buf)
+}
+
+// String returns the Summary of this resource.
+func (power *Power) String() string {
+	return power.Summary()
+}
+
+// appendStatus appends the health of a status to buf for the String methods,
+// or its state if it reports no health.
+func appendStatus(buf []byte, status common.Status) []byte {
+	switch {
+	case status.State == common.AbsentState:
+		return append(buf, "absent"...)
+	case status.Health != "":
+		return append(buf, status.Health...)
+	case status.State != "":
+		return append(buf, status.State...)
+	default:
+		return append(buf, "status unknown"...)
+	}
+}
+
+// AggregateEfficiency returns the efficiency of the present power supplies
+// taken together, as a percentage of total output over total input power.
+// False is returned if no supply reports its output and its input. See
+// AggregateEfficiencyEstimate for how missing input readings are handled.
+func (power *Power) AggregateEfficiency() (float64, bool) {
+	efficiency, _, ok := power.AggregateEfficiencyEstimate()
+	return efficiency, ok
+}
+
+// AggregateEfficiencyEstimate is AggregateEfficiency, also reporting whether
+// the result is estimated. The input of a supply that does not report
+// PowerInputWatts is estimated from its output and EfficiencyPercent. The
+// result is clamped to between 0 and 100 percent, since readings taken at
+// slightly different times can suggest more output than input.
+func (power *Power) AggregateEfficiencyEstimate() (efficiency float64, estimated, ok bool) {
+	var output, input float64
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if !supply.IsPresent() || supply.PowerOutputWatts <= 0 {
+			continue
+		}
+
+		switch {
+		case supply.PowerInputWatts > 0:
+			input += supply.PowerInputWatts
+		case supply.EfficiencyPercent > 0:
+			input += supply.PowerOutputWatts / (supply.EfficiencyPercent / 100)
+			estimated = true
+		default:
+			continue
+		}
+		output += supply.PowerOutputWatts
+	}
+
+	efficiency, ok = percentOf(output, input)
+	if !ok {
+		return 0, false, false
+	}
+
+	return math.Min(math.Max(efficiency, 0), 100), estimated, true
+}
+
+// TotalOutputWatts returns the summed PowerOutputWatts of the present power
+// supplies. The schema gives no way to tell a supply that never reported its
+// output from one reporting 0, such as a standby supply, so both add nothing
+// to the total, and 0 is returned if no supply reports a positive output.
+func (power *Power) TotalOutputWatts() float64 {
+	var total float64
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if supply.IsPresent() && supply.PowerOutputWatts > 0 {
+			total += supply.PowerOutputWatts
+		}
+	}
+
+	return total
+}
+
+// TotalInputWatts returns the summed PowerInputWatts of the present power
+// supplies. As for TotalOutputWatts, unreported and zero readings add
+// nothing to the total.
+func (power *Power) TotalInputWatts() float64 {
+	var total float64
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if supply.IsPresent() && supply.PowerInputWatts > 0 {
+			total += supply.PowerInputWatts
+		}
+	}
+
+	return total
+}
+
+// AverageEfficiencyPercent returns the mean efficiency of the present power
+// supplies, each counting equally regardless of its load. A supply's
+// efficiency is its EfficiencyPercent, or if that is not reported, its
+// PowerOutputWatts as a percentage of its PowerInputWatts. Supplies with
+// neither, including those reporting 0, are left out of the mean rather
+// than counted as 0% efficient. 0 is returned if no supply has an
+// efficiency. See AggregateEfficiency for the efficiency of the supplies
+// taken together.
+func (power *Power) AverageEfficiencyPercent() float64 {
+	var sum float64
+	var count int
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if !supply.IsPresent() {
+			continue
+		}
+
+		efficiency := supply.EfficiencyPercent
+		if efficiency <= 0 && supply.PowerOutputWatts > 0 {
+			efficiency, _ = percentOf(supply.PowerOutputWatts, supply.PowerInputWatts)
+		}
+		if efficiency > 0 {
+			sum += efficiency
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// DuplicateSensorNumbers returns, in ascending order, the SensorNumber values
+// shared by more than one voltage sensor of this resource, which the schema
+// does not allow. Voltages without a sensor number are ignored.
+func (power *Power) DuplicateSensorNumbers() []int {
+	counts := make(map[int]int)
+	for i := range power.Voltages {
+		if number := power.Voltages[i].SensorNumber; number != 0 {
+			counts[number]++
+		}
+	}
+
+	var result []int
+	for number, count := range counts {
+		if count > 1 {
+			result = append(result, number)
+		}
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// SupplyLoadDistribution returns the load percentage of each power supply,
+// keyed by MemberID. Supplies that do not report a capacity are omitted.
+func (power *Power) SupplyLoadDistribution() map[string]float64 {
+	result := make(map[string]float64)
+	for i := range power.PowerSupplies {
+		if load, ok := power.PowerSupplies[i].LoadPercent(); ok {
+			result[power.PowerSupplies[i].MemberID] = load
+		}
+	}
+
+	return result
+}
+
+// batchURI is the OData batch endpoint of a Redfish service.
+const batchURI = "/redfish/v1/$batch"
+
+// BatchChange is a single PATCH to apply as part of a BatchApply call.
+type BatchChange struct {
+	// URI is the resource to update.
+	URI string
+	// Payload is the set of properties to PATCH.
+	Payload interface{}
+}
+
+// BatchResult is the outcome of a single BatchChange.
+type BatchResult struct {
+	// URI is the resource that was updated.
+	URI string
+	// StatusCode is the HTTP status returned for the change.
+	StatusCode int
+	// Err is set if the change was not applied.
+	Err error
+}
+
+// batchRequest is an entry in an OData JSON batch request body.
+type batchRequest struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    interface{}       `json:"body"`
+}
+
+// batchResponse is an entry in an OData JSON batch response body.
+type batchResponse struct {
+	ID     string          `json:"id"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// BatchApply sends several PATCH requests, typically power limit changes, to
+// the service in a single OData batch request. If the service does not
+// support batching (the batch endpoint returns 404 or 501) the changes are
+// applied with one request each. The results are returned in the same order
+// as changes; an error is only returned if the batch could not be sent.
+func (power *Power) BatchApply(changes []BatchChange) ([]BatchResult, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	if power.Client == nil {
+		return nil, fmt.Errorf("power resource %q has no client", power.ODataID)
+	}
+
+	requests := make([]batchRequest, len(changes))
+	for i, change := range changes {
+		requests[i] = batchRequest{
+			ID:      strconv.Itoa(i),
+			Method:  http.MethodPatch,
+			URL:     change.URI,
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    change.Payload,
+		}
+	}
+
+	resp, err := power.Client.Post(batchURI, map[string]interface{}{"requests": requests})
+	if err != nil {
+		var redfishErr *common.Error
+		if errors.As(err, &redfishErr) &&
+			(redfishErr.HTTPReturnedStatusCode == http.StatusNotFound ||
+				redfishErr.HTTPReturnedStatusCode == http.StatusNotImplemented) {
+			return power.applySequentially(changes), nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var batch struct {
+		Responses []batchResponse `json:"responses"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&batch)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(changes))
+	for i, change := range changes {
+		results[i] = BatchResult{
+			URI: change.URI,
+			Err: fmt.Errorf("no response received for change to %s", change.URI),
+		}
+	}
+	for _, response := range batch.Responses {
+		i, err := strconv.Atoi(response.ID)
+		if err != nil || i < 0 || i >= len(results) {
+			continue
+		}
+		results[i].StatusCode = response.Status
+		results[i].Err = nil
+		if response.Status >= http.StatusBadRequest {
+			results[i].Err = common.ConstructError(response.Status, response.Body)
+		}
+	}
+
+	return results, nil
+}
+
+// applySequentially sends each change as its own PATCH request.
+func (power *Power) applySequentially(changes []BatchChange) []BatchResult {
+	results := make([]BatchResult, len(changes))
+	for i, change := range changes {
+		results[i].URI = change.URI
+		resp, err := power.Client.Patch(change.URI, change.Payload)
+		if err != nil {
+			results[i].Err = err
+			var redfishErr *common.Error
+			if errors.As(err, &redfishErr) {
+				results[i].StatusCode = redfishErr.HTTPReturnedStatusCode
+			}
+			continue
+		}
+		results[i].StatusCode = resp.StatusCode
+		resp.Body.Close()
+	}
+
+	return results
+}
+
+// ListReferencedPowers gets the collection of Power from
+// a provided reference.
+func ListReferencedPowers(c common.Client, link string) ([]*Power, error) { //nolint:dupl
+	return ListReferencedPowersWithContext(context.Background(), c, link)
+}
+
+// defaultPowerFetchConcurrency is how many Power resources
+// ListReferencedPowers fetches at a time.
+const defaultPowerFetchConcurrency = 4
+
+// ListReferencedPowersWithContext gets the collection of Power from a
+// provided reference, giving up when ctx is done. Members not fetched by
+// then are reported as failed with the error of ctx.
+func ListReferencedPowersWithContext(ctx context.Context, c common.Client, link string) ([]*Power, error) {
+	return ListReferencedPowersWithConcurrency(ctx, c, link, defaultPowerFetchConcurrency)
+}
+
+// ListReferencedPowersWithConcurrency is ListReferencedPowersWithContext,
+// fetching up to workers members of the collection at a time. The result is
+// in the order of the collection regardless.
+func ListReferencedPowersWithConcurrency(ctx context.Context, c common.Client, link string, workers int) ([]*Power, error) {
+	var result []*Power
+	if link == "" {
+		return result, nil
+	}
+	if workers <= 0 {
+		workers = defaultPowerFetchConcurrency
+	}
+
+	links, err := common.GetCollectionWithContext(ctx, c, link)
+	if err != nil {
+		common.GetLogger().Errorf("gofish: getting power collection %s: %v", link, err)
+		return result, common.ClassifyError(link, err)
+	}
+
+	powers := make([]*Power, len(links.ItemLinks))
+	err = common.ResolveLinks(links.ItemLinks, workers, func(i int, powerLink string) (err error) {
+		powers[i], err = GetPowerWithContext(ctx, c, powerLink)
+		return err
+	})
+
+	for _, power := range powers {
+		if power != nil {
+			result = append(result, power)
+		}
+	}
+
+	return result, err
+}
+
+// ListReferencedPowersExpanded gets the collection of Power from a provided
+// reference, asking the service with $expand to return the members inline
+// so the collection is read with a single request. Members the service
+// returns only as references, such as when it does not support $expand, are
+// fetched one at a time instead.
+func ListReferencedPowersExpanded(c common.Client, link string) ([]*Power, error) {
+	var result []*Power
+	if link == "" {
+		return result, nil
+	}
+
+	uri := common.NewQuery().WithExpandLevels(1).Apply(link)
+	resp, err := c.Get(uri)
+	if err != nil {
+		common.GetLogger().Errorf("gofish: getting power collection %s: %v", uri, err)
+		return result, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var collection struct {
+		Members []json.RawMessage
+	}
+	err = json.NewDecoder(resp.Body).Decode(&collection)
+	if err != nil {
+		return result, &common.ParseError{URI: uri, Err: err}
+	}
+
+	collectionError := common.NewCollectionError()
+	for i, member := range collection.Members {
+		var properties map[string]json.RawMessage
+		var powerLink string
+		if json.Unmarshal(member, &properties) == nil {
+			_ = json.Unmarshal(properties["@odata.id"], &powerLink)
+		}
+		if powerLink == "" {
+			powerLink = fmt.Sprintf("%s#/Members/%d", link, i)
+		}
+
+		var power *Power
+		switch {
+		case len(properties) == 0:
+			err = &common.ParseError{URI: powerLink, Err: fmt.Errorf("member %d of %s is not an object", i, link)}
+		case len(properties) == 1 && properties["@odata.id"] != nil:
+			// Only a reference, so the member was not expanded
+			power, err = GetPower(c, powerLink)
+		default:
+			power, err = decodePower(c, powerLink, member, http.Header{})
+		}
+
+		if err != nil {
+			collectionError.Failures[powerLink] = err
+		} else {
+			result = append(result, power)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// IterateReferencedPowers gets the members of the collection of Power from a
+// provided reference one at a time, passing each to fn, so that large
+// collections can be processed without holding every member in memory.
+// Members that cannot be fetched are skipped and reported together as a
+// CollectionError once the collection is done. If fn returns an error, the
+// iteration stops and that error is returned. When ctx is done, the remaining
+// members are reported as failed with the error of ctx.
+func IterateReferencedPowers(ctx context.Context, c common.Client, link string, fn func(*Power) error) error {
+	if link == "" {
+		return nil
+	}
+
+	links, err := common.GetCollectionWithContext(ctx, c, link)
+	if err != nil {
+		common.GetLogger().Errorf("gofish: getting power collection %s: %v", link, err)
+		return common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, powerLink := range links.ItemLinks {
+		if err := ctx.Err(); err != nil {
+			collectionError.Failures[powerLink] = err
+			continue
+		}
+
+		power, err := GetPowerWithContext(ctx, c, powerLink)
+		if err != nil {
+			collectionError.Failures[powerLink] = err
+			continue
+		}
+
+		if err := fn(power); err != nil {
+			return err
+		}
+	}
+
+	if collectionError.Empty() {
+		return nil
+	}
+
+	return collectionError
+}
+
+// PowerControl is
+type PowerControl struct {
+	common.Entity
+
+	// MemberID shall uniquely identify the member within the collection. For
+	// services supporting Redfish v1.6 or higher, this value shall be the
+	// zero-based array index.
+	MemberID string `json:"MemberId"`
+	// Oem contains the raw vendor specific properties of this power control.
+	// Use GetOem to decode them.
+	Oem json.RawMessage
+	// PhysicalContext shall be a description of the affected device(s) or region
+	// within the chassis to which this power control applies.
+	PhysicalContext common.PhysicalContext
+	// PowerAllocatedWatts shall represent the total power currently allocated
+	// to chassis resources.
+	PowerAllocatedWatts float64
+	// PowerAvailableWatts shall represent the amount of power capacity (in
+	// Watts) not already allocated and shall equal PowerCapacityWatts -
+	// PowerAllocatedWatts.
+	PowerAvailableWatts float64
+	// PowerCapacityWatts shall represent the total power capacity that is
+	// available for allocation to the chassis resources.
+	PowerCapacityWatts float64
+	// PowerConsumedWatts shall represent the actual power being consumed (in
+	// Watts) by the chassis.
+	PowerConsumedWatts float64
+	// PowerConsumedWattsExcerpt is set if the service reported
+	// PowerConsumedWatts as an excerpt of a Sensor, as newer services do,
+	// rather than as a number. Its Reading is also kept in
+	// PowerConsumedWatts.
+	PowerConsumedWattsExcerpt *SensorExcerpt `json:"-"`
+	// PowerLimit shall contain power limit status and configuration information
+	// for this chassis.
+	PowerLimit PowerLimit
+	// PowerMetrics shall contain power metrics for power readings (interval,
+	// minimum/maximum/average power consumption) for the chassis.
+	PowerMetrics PowerMetric
+	// PowerRequestedWatts shall represent the
+	// amount of power (in Watts) that the chassis resource is currently
+	// requesting be budgeted to it for future use.
+	PowerRequestedWatts float64
+	// ReadingRangeMax is the highest PowerConsumedWatts value the sensor can
+	// report, where provided by the service.
+	ReadingRangeMax float64
+	// ReadingRangeMin is the lowest PowerConsumedWatts value the sensor can
+	// report, where provided by the service.
+	ReadingRangeMin float64
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// allowedMethods caches the Allow header of the response this control
+	// was read from.
+	allowedMethods []string
+	// capacityReported and consumedReported record whether the service
+	// reported PowerCapacityWatts and PowerConsumedWatts.
+	capacityReported bool
+	consumedReported bool
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// isMemberIDTypeError reports whether err was caused by a MemberId that is not
+// a string. Some Dell implementations return MemberId as an integer.
+func isMemberIDTypeError(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &typeErr) && typeErr.Field == "MemberId"
+}
+
+// UnmarshalJSON unmarshals a PowerControl object from the raw JSON.
+func (powercontrol *PowerControl) UnmarshalJSON(b []byte) error { // nolint:dupl
+	type temp PowerControl
+	type t1 struct {
+		temp
+		PhysicalContext     json.RawMessage
+		PowerAllocatedWatts common.FlexFloat
+		PowerAvailableWatts common.FlexFloat
+		PowerCapacityWatts  common.FlexFloat
+		PowerConsumedWatts  json.RawMessage
+		PowerRequestedWatts common.FlexFloat
+	}
+	var t t1
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		if !isMemberIDTypeError(err) {
+			return err
+		}
+
+		// Handle converting a numeric MemberID
+		var t2 struct {
+			t1
+			MemberID int `json:"MemberId"`
+		}
+		err2 := json.Unmarshal(b, &t2)
+
+		if err2 != nil {
+			// Return the original error
+			return err
+		}
+
+		// Convert the numeric member ID to a string
+		t = t2.t1
+		t.temp.MemberID = strconv.Itoa(t2.MemberID)
+		common.GetLogger().Debugf("gofish: converted numeric PowerControl MemberId %d", t2.MemberID)
+	}
+
+	// Extract the links to other entities for later
+	*powercontrol = PowerControl(t.temp)
+	powercontrol.PhysicalContext = decodePhysicalContext(t.PhysicalContext)
+	powercontrol.PowerAllocatedWatts = t.PowerAllocatedWatts.Float64()
+	powercontrol.PowerAvailableWatts = t.PowerAvailableWatts.Float64()
+	powercontrol.PowerCapacityWatts = t.PowerCapacityWatts.Float64()
+	err = powercontrol.decodeConsumedWatts(t.PowerConsumedWatts)
+	if err != nil {
+		return err
+	}
+	powercontrol.PowerRequestedWatts = t.PowerRequestedWatts.Float64()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	powercontrol.rawData = b
+
+	// Tell readings that are missing or null apart from a reading of zero
+	var reported struct {
+		PowerCapacityWatts *json.RawMessage
+		PowerConsumedWatts *json.RawMessage
+	}
+	if json.Unmarshal(b, &reported) == nil {
+		powercontrol.capacityReported = reported.PowerCapacityWatts != nil
+		powercontrol.consumedReported = reported.PowerConsumedWatts != nil
+	}
+
+	return nil
+}
+
+// decodeConsumedWatts decodes PowerConsumedWatts, which is either a number
+// or an excerpt of the Sensor providing the reading.
+func (powercontrol *PowerControl) decodeConsumedWatts(b json.RawMessage) error {
+	consumed, excerpt, err := decodeConsumedWattsReading(b)
+	if err != nil {
+		return err
+	}
+	powercontrol.PowerConsumedWatts = consumed
+	powercontrol.PowerConsumedWattsExcerpt = excerpt
+	return nil
+}
+
+// decodeConsumedWattsReading decodes a PowerConsumedWatts value, returning
+// the Sensor excerpt it was read from if it was given as one.
+func decodeConsumedWattsReading(b json.RawMessage) (float64, *SensorExcerpt, error) {
+	if len(b) == 0 {
+		return 0, nil, nil
+	}
+
+	if isJSONObject(b) {
+		var excerpt SensorExcerpt
+		if err := json.Unmarshal(b, &excerpt); err != nil {
+			return 0, nil, err
+		}
+		return excerpt.Reading, &excerpt, nil
+	}
+
+	var consumed common.FlexFloat
+	if err := json.Unmarshal(b, &consumed); err != nil {
+		return 0, nil, err
+	}
+	return consumed.Float64(), nil, nil
+}
+
+// MarshalJSON marshals the power control into its Redfish form, with
+// MemberId as a string. A PowerCapacityWatts or PowerConsumedWatts that the
+// service did not report is left out rather than sent as 0, and a
+// PowerConsumedWatts reported as a Sensor excerpt is kept as one.
+func (powercontrol *PowerControl) MarshalJSON() ([]byte, error) {
+	type temp PowerControl
+	t := struct {
+		temp
+		Oem                json.RawMessage `json:",omitempty"`
+		PowerCapacityWatts *float64        `json:",omitempty"`
+		PowerConsumedWatts interface{}     `json:",omitempty"`
+	}{
+		temp: temp(*powercontrol),
+		Oem:  powercontrol.Oem,
+	}
+
+	// A control that was not decoded from the service has no record of what
+	// was reported, so its values are all kept
+	decoded := powercontrol.rawData != nil
+	if powercontrol.capacityReported || !decoded {
+		t.PowerCapacityWatts = &powercontrol.PowerCapacityWatts
+	}
+	if powercontrol.consumedReported || !decoded {
+		t.PowerConsumedWatts = &powercontrol.PowerConsumedWatts
+		if excerpt := powercontrol.PowerConsumedWattsExcerpt; excerpt != nil {
+			t.PowerConsumedWatts = &SensorExcerpt{
+				DataSourceURI: excerpt.DataSourceURI,
+				Reading:       powercontrol.PowerConsumedWatts,
+			}
+		}
+	}
+
+	return json.Marshal(t)
+}
+
+// GetOem unmarshals the raw Oem properties of the power control into
+// target. If no Oem properties were reported, target is left unchanged.
+func (powercontrol *PowerControl) GetOem(target interface{}) error {
+	return decodeOem(powercontrol.Oem, target)
+}
+
+// CapacityWatts returns PowerCapacityWatts, or an absent value if the
+// service did not report it.
+func (powercontrol *PowerControl) CapacityWatts() Watts {
+	if powercontrol == nil || !powercontrol.capacityReported {
+		return Watts{}
+	}
+
+	return NewWatts(powercontrol.PowerCapacityWatts)
+}
+
+// ConsumedWatts returns PowerConsumedWatts, or an absent value if the
+// service did not report it.
+func (powercontrol *PowerControl) ConsumedWatts() Watts {
+	if powercontrol == nil || !powercontrol.consumedReported {
+		return Watts{}
+	}
+
+	return NewWatts(powercontrol.PowerConsumedWatts)
+}
+
+// ConsumedWattsSensor gets the Sensor providing PowerConsumedWatts, or nil if
+// the service did not report PowerConsumedWatts as an excerpt of a Sensor
+// with a data source.
+func (powercontrol *PowerControl) ConsumedWattsSensor() (*Sensor, error) {
+	if powercontrol.PowerConsumedWattsExcerpt == nil {
+		return nil, nil
+	}
+
+	return powercontrol.PowerConsumedWattsExcerpt.Sensor(powercontrol.Client)
+}
+
+// allocationToleranceWatts is how far PowerAvailableWatts may be from
+// PowerCapacityWatts - PowerAllocatedWatts before the allocation is taken to
+// be inconsistent, allowing for services rounding each value to whole watts.
+const allocationToleranceWatts = 1
+
+// AllocationConsistent reports whether PowerAvailableWatts equals
+// PowerCapacityWatts - PowerAllocatedWatts, as the schema requires, within a
+// watt. Services that report inconsistent values cannot be trusted for
+// capacity planning.
+func (powercontrol *PowerControl) AllocationConsistent() bool {
+	expected := powercontrol.PowerCapacityWatts - powercontrol.PowerAllocatedWatts
+	return math.Abs(powercontrol.PowerAvailableWatts-expected) <= allocationToleranceWatts
+}
+
+// HeadroomWatts returns the power that can still be allocated, which is
+// PowerCapacityWatts - PowerAllocatedWatts, or PowerAvailableWatts if the
+// service did not report PowerCapacityWatts. It is never negative, even if
+// more power is allocated than the capacity.
+func (powercontrol *PowerControl) HeadroomWatts() float64 {
+	headroom := powercontrol.PowerAvailableWatts
+	if powercontrol.capacityReported || powercontrol.rawData == nil {
+		headroom = powercontrol.PowerCapacityWatts - powercontrol.PowerAllocatedWatts
+	}
+
+	return math.Max(headroom, 0)
+}
+
+// String returns a one line description of the control for logging, such as
+// "PowerControl[0] 344W/800W, limit 500W, OK".
+func (powercontrol *PowerControl) String() string {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "PowerControl["...)
+	buf = append(buf, powercontrol.MemberID...)
+	buf = append(buf, "] "...)
+
+	consumed, capacity := powercontrol.ConsumedWatts(), powercontrol.CapacityWatts()
+	if consumed.Present {
+		buf = strconv.AppendFloat(buf, consumed.Value, 'f', 0, 64)
+		buf = append(buf, 'W')
+	} else {
+		buf = append(buf, "consumption unknown"...)
+	}
+	if capacity.Present {
+		buf = append(buf, '/')
+		buf = strconv.AppendFloat(buf, capacity.Value, 'f', 0, 64)
+		buf = append(buf, 'W')
+	}
+	if powercontrol.PowerLimit.LimitInWatts > 0 {
+		buf = append(buf, ", limit "...)
+		buf = strconv.AppendFloat(buf, powercontrol.PowerLimit.LimitInWatts, 'f', 0, 64)
+		buf = append(buf, 'W')
+	}
+
+	buf = append(buf, ", "...)
+	buf = appendStatus(buf, powercontrol.Status)
+	return string(buf)
+}
+
+// IsConsumptionPlausible reports whether PowerConsumedWatts lies within the
+// reading range declared by the service. A reading outside the range usually
+// indicates a faulty sensor. True is returned if no range is declared.
+func (powercontrol *PowerControl) IsConsumptionPlausible() bool {
+	return readingInRange(powercontrol.PowerConsumedWatts,
+		powercontrol.ReadingRangeMin, powercontrol.ReadingRangeMax)
+}
+
+// readingInRange reports whether reading lies within [min, max]. A range with
+// a maximum not above its minimum is treated as undeclared and always matches.
+func readingInRange(reading, min, max float64) bool {
+	if max <= min {
+		return true
+	}
+
+	return reading >= min && reading <= max
+}
+
+// percentOf returns value as a percentage of capacity. False is returned
+// rather than an infinite or NaN result if capacity is not positive, so that
+// callers aggregating percentages are not poisoned by a single bad reading.
+func percentOf(value, capacity float64) (float64, bool) {
+	if capacity <= 0 {
+		return 0, false
+	}
+
+	result := value / capacity * 100
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, false
+	}
+
+	return result, true
+}
+
+// powerLimitToleranceWatts is how close a reported power limit must be to
+// the requested value to be considered applied.
+const powerLimitToleranceWatts = 0.5
+
+// WaitForLimit polls the service every poll interval (one second if poll is
+// not positive) until this control reports a PowerLimit.LimitInWatts within
+// half a watt of want, or ctx is done. On success the control's PowerLimit is
+// updated with the reported value. If ctx ends first the returned error
+// includes the last observed limit.
+func (powercontrol *PowerControl) WaitForLimit(ctx context.Context, want float64, poll time.Duration) error {
+	if poll <= 0 {
+		poll = time.Second
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		current, err := powercontrol.fetchCurrent()
+		if err != nil {
+			return err
+		}
+
+		if math.Abs(current.PowerLimit.LimitInWatts-want) <= powerLimitToleranceWatts {
+			powercontrol.PowerLimit = current.PowerLimit
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("power limit did not reach %g W, last observed %g W: %w",
+				want, current.PowerLimit.LimitInWatts, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// SupportedMethods returns the HTTP methods allowed on the resource holding
+// this power control, so callers can tell a read-only service before trying
+// to change a limit. The Allow header of the GET the control was read with is
+// used if the service sent one; otherwise an OPTIONS request is issued, which
+// requires a client implementing common.RawClient.
+func (powercontrol *PowerControl) SupportedMethods(c common.Client) ([]string, error) {
+	if len(powercontrol.allowedMethods) > 0 {
+		return powercontrol.allowedMethods, nil
+	}
+
+	uri := strings.SplitN(powercontrol.ODataID, "#", 2)[0]
+	if uri == "" {
+		return nil, fmt.Errorf("no resource URI for %q", powercontrol.ODataID)
+	}
+
+	rawClient, ok := c.(common.RawClient)
+	if !ok {
+		return nil, fmt.Errorf("client does not support OPTIONS requests")
+	}
+
+	resp, err := rawClient.RunRawRequestWithHeaders(http.MethodOptions, uri, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	powercontrol.allowedMethods = parseAllowHeader(resp.Header.Get("Allow"))
+	return powercontrol.allowedMethods, nil
+}
+
+// parseAllowHeader splits the value of an Allow header into its methods.
+func parseAllowHeader(allow string) []string {
+	var methods []string
+	for _, method := range strings.Split(allow, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			methods = append(methods, strings.ToUpper(method))
+		}
+	}
+
+	return methods
+}
+
+// SetPowerLimit applies a power cap by updating the PowerLimit of this control
+// in its parent Power resource. A nil limitWatts disables capping. The
+// exception and correctionMs are left unchanged on the service if they are
+// empty or zero. On success PowerLimit is updated to match the request.
+func (powercontrol *PowerControl) SetPowerLimit(limitWatts *float64, exception PowerLimitException, correctionMs int64) error {
+	limit := map[string]interface{}{"LimitInWatts": limitWatts}
+	if exception != "" {
+		limit["LimitException"] = exception
+	}
+	if correctionMs != 0 {
+		limit["CorrectionInMs"] = correctionMs
+	}
+
+	err := powercontrol.patch(map[string]interface{}{"PowerLimit": limit})
+	if err != nil {
+		return err
+	}
+
+	powercontrol.PowerLimit.LimitInWatts = 0
+	if limitWatts != nil {
+		powercontrol.PowerLimit.LimitInWatts = *limitWatts
+	}
+	if exception != "" {
+		powercontrol.PowerLimit.LimitException = exception
+	}
+	if correctionMs != 0 {
+		powercontrol.PowerLimit.CorrectionInMs = correctionMs
+	}
+
+	return nil
+}
+
+// powerLimitWritableFields are the PowerLimit properties that may be changed
+// through PowerControl.Update.
+var powerLimitWritableFields = []string{
+	"CorrectionInMs",
+	"LimitException",
+	"LimitInWatts",
+}
+
+// Update commits updates to the PowerLimit of this control to its parent
+// Power resource. The other properties of a PowerControl are read only. If
+// the service rejects the change, a *common.RedfishError is returned.
+func (powercontrol *PowerControl) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(PowerControl)
+	err := original.UnmarshalJSON(powercontrol.rawData)
+	if err != nil {
+		return err
+	}
+
+	// Entity.Update skips nested objects, so with nothing allowed it only
+	// rejects changes to the read only properties
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(powercontrol).Elem()
+	err = powercontrol.Entity.Update(originalElement, currentElement, nil)
+	if err != nil {
+		return err
+	}
+
+	limit := make(map[string]interface{})
+	originalLimit := reflect.ValueOf(original.PowerLimit)
+	currentLimit := reflect.ValueOf(powercontrol.PowerLimit)
+	for i := 0; i < originalLimit.NumField(); i++ {
+		if originalLimit.Field(i).Interface() != currentLimit.Field(i).Interface() {
+			limit[originalLimit.Type().Field(i).Name] = currentLimit.Field(i).Interface()
+		}
+	}
+	for field := range limit {
+		found := false
+		for _, name := range powerLimitWritableFields {
+			if name == field {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("PowerLimit.%s field is read only", field)
+		}
+	}
+	if len(limit) == 0 {
+		return nil
+	}
+
+	return powercontrol.patch(map[string]interface{}{"PowerLimit": limit})
+}
+
+// patch sends body as the update of this control in the PowerControl array
+// of its parent Power resource.
+func (powercontrol *PowerControl) patch(body map[string]interface{}) error {
+	uri := strings.SplitN(powercontrol.ODataID, "#", 2)[0]
+	if uri == "" {
+		return fmt.Errorf("no resource URI for power control %q", powercontrol.MemberID)
+	}
+	index, err := powercontrol.arrayIndex()
+	if err != nil {
+		return err
+	}
+
+	controls := arrayPatch(map[int]interface{}{index: body})
+	resp, err := powercontrol.Client.Patch(uri, map[string]interface{}{"PowerControl": controls})
+	if err != nil {
+		return common.ClassifyError(uri, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// arrayIndex returns the position of this control in the PowerControl array
+// of its parent, from the fragment of its ODataID or else its MemberID.
+func (powercontrol *PowerControl) arrayIndex() (int, error) {
+	return embeddedIndex(powercontrol.ODataID, "PowerControl", powercontrol.MemberID)
+}
+
+// embeddedIndex returns the position of a member embedded in the given array
+// property of its parent, from the fragment of its ODataID, such as
+// /redfish/v1/Chassis/1/Power#/PowerControl/0, or else its MemberID.
+func embeddedIndex(odataID, property, memberID string) (int, error) {
+	parts := strings.SplitN(odataID, "#", 2)
+	if len(parts) == 2 && strings.HasPrefix(parts[1], "/"+property+"/") {
+		if index, err := strconv.Atoi(strings.TrimPrefix(parts[1], "/"+property+"/")); err == nil && index >= 0 {
+			return index, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(memberID); err == nil && index >= 0 {
+		return index, nil
+	}
+
+	return 0, fmt.Errorf("cannot determine the position of %s member %q in %s", property, memberID, parts[0])
+}
+
+// arrayPatch returns the value of an array property that PATCHes the members
+// at the given positions. Members of a PATCHed array are matched by
+// position, so the others are sent as empty objects, which leave them
+// unchanged.
+func arrayPatch(changes map[int]interface{}) []interface{} {
+	size := 0
+	for index := range changes {
+		if index+1 > size {
+			size = index + 1
+		}
+	}
+
+	result := make([]interface{}, size)
+	for i := range result {
+		if change, ok := changes[i]; ok {
+			result[i] = change
+		} else {
+			result[i] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// embeddedPatchClient sends the PATCH requests for a member embedded in an
+// array property of its parent to the parent instead, as the change of the
+// member at its position in the array. The fragment of the URI of an
+// embedded member is not sent to the service, so PATCHing it directly would
+// change the parent itself.
+type embeddedPatchClient struct {
+	common.Client
+	uri      string
+	property string
+	index    int
+}
+
+// PatchWithHeaders sends payload as the change of the member.
+func (c *embeddedPatchClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	body := map[string]interface{}{c.property: arrayPatch(map[int]interface{}{c.index: payload})}
+	return c.Client.PatchWithHeaders(c.uri, body, customHeaders)
+}
+
+// Patch sends payload as the change of the member.
+func (c *embeddedPatchClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return c.PatchWithHeaders(url, payload, nil)
 }
 
-// UnmarshalJSON unmarshals a PowerControl object from the raw JSON.
-func (powercontrol *PowerControl) UnmarshalJSON(b []byte) error { // nolint:dupl
-	type temp PowerControl
-	type t1 struct {
-		temp
+// fetchCurrent retrieves the current state of this control from its parent
+// Power resource.
+func (powercontrol *PowerControl) fetchCurrent() (*PowerControl, error) {
+	power, err := getParentPower(powercontrol.Client, powercontrol.ODataID)
+	if err != nil {
+		return nil, err
 	}
-	var t t1
 
-	err := json.Unmarshal(b, &t)
-	if err != nil {
-		fmt.Println("*******power.go UnmarshalJSON powercontrol 解析有报错！")
-		// See if we need to handle converting MemberID
-		var t2 struct {
-			t1
-			MemberID int `json:"MemberId"`
+	for i := range power.PowerControl {
+		control := &power.PowerControl[i]
+		if (powercontrol.MemberID != "" && control.MemberID == powercontrol.MemberID) ||
+			(powercontrol.MemberID == "" && control.ODataID == powercontrol.ODataID) {
+			return control, nil
 		}
-		err2 := json.Unmarshal(b, &t2)
+	}
 
-		if err2 != nil {
-			// Return the original error
-			return err
-		}
+	return nil, fmt.Errorf("power control %q not found in %s", powercontrol.MemberID, power.ODataID)
+}
 
-		// Convert the numeric member ID to a string
-		t = t2.t1
-		t.temp.MemberID = strconv.Itoa(t2.MemberID)
-		fmt.Println("*****power.go powercontrol 解析结果: ", t.temp)
+// getParentPower retrieves the Power resource containing the array member
+// identified by odataID.
+func getParentPower(c common.Client, odataID string) (*Power, error) {
+	// Array members are addressed as fragments of the Power resource
+	// (e.g. /redfish/v1/Chassis/1/Power#/PowerControl/0)
+	uri := strings.SplitN(odataID, "#", 2)[0]
+	if uri == "" {
+		return nil, fmt.Errorf("no resource URI for %q", odataID)
 	}
 
-	// Extract the links to other entities for later
-	*powercontrol = PowerControl(t.temp)
+	var power Power
+	err := getJSON(c, uri, &power)
+	if err != nil {
+		return nil, err
+	}
+	if power.ODataID == "" {
+		power.ODataID = uri
+	}
 
-	return nil
+	return &power, nil
 }
 
 // PowerLimit shall contain power limit status and
@@ -372,6 +3037,7 @@ type PowerMetric struct {
 	// interval (or window), in minutes, in which the PowerMetrics properties
 	// are measured over.
 	// Should be an integer, but some Dell implementations return as a float.
+	// Use IntervalMinutes for the whole number of minutes.
 	IntervalInMin float64
 	// MaxConsumedWatts shall represent the
 	// maximum power level in watts that occurred within the last
@@ -381,6 +3047,55 @@ type PowerMetric struct {
 	// minimum power level in watts that occurred within the last
 	// IntervalInMin minutes.
 	MinConsumedWatts float64
+	// SensorResetTime is when the metric window was last reset, where the
+	// service reports it.
+	SensorResetTime time.Time
+}
+
+// UnmarshalJSON unmarshals a PowerMetric object from the raw JSON.
+func (powermetric *PowerMetric) UnmarshalJSON(b []byte) error {
+	type temp PowerMetric
+	var t struct {
+		temp
+		AverageConsumedWatts common.FlexFloat
+		MaxConsumedWatts     common.FlexFloat
+		MinConsumedWatts     common.FlexFloat
+		SensorResetTime      string
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powermetric = PowerMetric(t.temp)
+	powermetric.AverageConsumedWatts = t.AverageConsumedWatts.Float64()
+	powermetric.MaxConsumedWatts = t.MaxConsumedWatts.Float64()
+	powermetric.MinConsumedWatts = t.MinConsumedWatts.Float64()
+	// An invalid or missing timestamp just leaves the reset time unknown
+	if resetTime, err := time.Parse(time.RFC3339, t.SensorResetTime); err == nil {
+		powermetric.SensorResetTime = resetTime
+	}
+
+	return nil
+}
+
+// IntervalMinutes returns IntervalInMin rounded to the nearest whole minute,
+// so a value reported as 4.9999 or 5.0 is 5 rather than being truncated.
+// IntervalInMin keeps the value as reported.
+func (powermetric *PowerMetric) IntervalMinutes() int {
+	return int(math.Round(powermetric.IntervalInMin))
+}
+
+// TimeSinceReset returns how long ago the metric window was reset, which
+// tells how much history backs the minimum, maximum and average values.
+// False is returned if the service does not report a reset time.
+func (powermetric *PowerMetric) TimeSinceReset(now time.Time) (time.Duration, bool) {
+	if powermetric.SensorResetTime.IsZero() {
+		return 0, false
+	}
+
+	return now.Sub(powermetric.SensorResetTime), true
 }
 
 // PowerSupply is Details of a power supplies associated with this system
@@ -410,6 +3125,8 @@ type PowerSupply struct {
 	IndicatorLED common.IndicatorLED
 	// InputRanges shall be a collection of ranges usable by the power supply unit.
 	InputRanges []InputRange
+	// inputCurrentAmps is the measured input current, if reported.
+	inputCurrentAmps *float64
 	// LastPowerOutputWatts shall contain the average power
 	// output, measured in Watts, of the associated power supply.
 	LastPowerOutputWatts float64
@@ -423,6 +3140,10 @@ type PowerSupply struct {
 	// Location shall contain location information of the
 	// associated power supply.
 	Location common.Location
+	// ManufactureDate is the date the power supply was manufactured, if
+	// reported by the service either as a standard or an OEM property. It is
+	// the zero time when no date could be parsed.
+	ManufactureDate time.Time
 	// Manufacturer shall be the name of the
 	// organization responsible for producing the power supply. This
 	// organization might be the entity from whom the power supply is
@@ -435,6 +3156,11 @@ type PowerSupply struct {
 	// Model shall contain the model information as defined
 	// by the manufacturer for the associated power supply.
 	Model string
+	// Oem contains the raw vendor specific properties of this power supply.
+	// Use GetOem to decode them.
+	Oem json.RawMessage
+	// outputCurrentAmps is the measured output current, if reported.
+	outputCurrentAmps *float64
 	// PartNumber shall contain the part number as defined
 	// by the manufacturer for the associated power supply.
 	PartNumber string
@@ -442,6 +3168,9 @@ type PowerSupply struct {
 	// of power, in Watts, that the associated power supply is rated to
 	// deliver.
 	PowerCapacityWatts float64
+	// PowerFactor shall contain the measured power factor of the input of the
+	// associated power supply, between 0 and 1. It is zero if not reported.
+	PowerFactor float64
 	// PowerInputWatts shall contain the value of the
 	// measured input power, in Watts, of the associated power supply.
 	PowerInputWatts float64
@@ -467,6 +3196,16 @@ type PowerSupply struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// indicatorLEDReported records whether the service reported IndicatorLED.
+	indicatorLEDReported bool
+	// writeableProperties lists the properties the service reported as
+	// writable, if it did.
+	writeableProperties []string
+	// redundancy holds the links to the redundancy groups of the supply.
+	redundancy []string
+	// etag is the entity tag of the representation the supply was read from,
+	// sent as If-Match when updating.
+	etag string
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -476,7 +3215,18 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	type temp PowerSupply
 	var t struct {
 		temp
-		Assembly common.Link
+		Assembly             common.Link
+		InputCurrentAmps     *float64
+		IndicatorLED         *common.IndicatorLED
+		LastPowerOutputWatts common.FlexFloat
+		ManufactureDate      string
+		Oem                  json.RawMessage
+		ODataEtag            string `json:"@odata.etag"`
+		OutputCurrentAmps    *float64
+		PowerCapacityWatts   common.FlexFloat
+		PowerInputWatts      common.FlexFloat
+		PowerOutputWatts     common.FlexFloat
+		WriteableProperties  []string `json:"@Redfish.WriteableProperties"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -487,6 +3237,43 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*powersupply = PowerSupply(t.temp)
 	powersupply.assembly = string(t.Assembly)
+	powersupply.inputCurrentAmps = t.InputCurrentAmps
+	powersupply.outputCurrentAmps = t.OutputCurrentAmps
+	powersupply.LastPowerOutputWatts = t.LastPowerOutputWatts.Float64()
+	powersupply.PowerCapacityWatts = t.PowerCapacityWatts.Float64()
+	powersupply.PowerInputWatts = t.PowerInputWatts.Float64()
+	powersupply.PowerOutputWatts = t.PowerOutputWatts.Float64()
+	if t.IndicatorLED != nil {
+		powersupply.IndicatorLED = *t.IndicatorLED
+	}
+	powersupply.indicatorLEDReported = t.IndicatorLED != nil
+	powersupply.writeableProperties = t.WriteableProperties
+	powersupply.etag = t.ODataEtag
+	powersupply.Oem = t.Oem
+
+	// The redundancy groups are often only links to the Redundancy array of
+	// the Power resource
+	var redundancy struct {
+		Redundancy common.Links
+	}
+	if json.Unmarshal(b, &redundancy) == nil {
+		powersupply.redundancy = redundancy.Redundancy.ToStrings()
+	}
+
+	// Not part of the PowerSupply schema, but some vendors report it directly
+	// or under their OEM object.
+	manufactureDate := t.ManufactureDate
+	var vendors map[string]json.RawMessage
+	_ = json.Unmarshal(t.Oem, &vendors)
+	for _, oem := range vendors {
+		var vendor struct {
+			ManufactureDate string
+		}
+		if manufactureDate == "" && json.Unmarshal(oem, &vendor) == nil {
+			manufactureDate = vendor.ManufactureDate
+		}
+	}
+	powersupply.ManufactureDate = parseManufactureDate(manufactureDate)
 
 	// This is a read/write object, so we need to save the raw object data for later
 	powersupply.rawData = b
@@ -494,8 +3281,135 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GetOem unmarshals the raw Oem properties of the power supply into target.
+// If no Oem properties were reported, target is left unchanged.
+func (powersupply *PowerSupply) GetOem(target interface{}) error {
+	return decodeOem(powersupply.Oem, target)
+}
+
+// RedundancyGroups gets the redundancy groups this power supply is a member
+// of. Groups that are members of the Redundancy array of a Power resource
+// are read from that resource, fetching it once for all such groups.
+func (powersupply *PowerSupply) RedundancyGroups() ([]*Redundancy, error) {
+	var result []*Redundancy
+	parents := make(map[string]*Power)
+
+	collectionError := common.NewCollectionError()
+	for _, link := range powersupply.redundancy {
+		redundancy, err := getRedundancyMember(powersupply.Client, link, parents)
+		if err != nil {
+			collectionError.Failures[link] = err
+		} else {
+			result = append(result, redundancy)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// getRedundancyMember gets the Redundancy at link, which may address a member
+// of the Redundancy array of a Power resource (such as
+// /redfish/v1/Chassis/1/Power#/Redundancy/0). Power resources fetched are
+// kept in parents for reuse.
+func getRedundancyMember(c common.Client, link string, parents map[string]*Power) (*Redundancy, error) {
+	parts := strings.SplitN(link, "#", 2)
+	if len(parts) == 1 {
+		return GetRedundancy(c, link)
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(parts[1], "/Redundancy/"))
+	if err != nil || !strings.HasPrefix(parts[1], "/Redundancy/") || index < 0 {
+		return nil, fmt.Errorf("unsupported redundancy reference %q", link)
+	}
+
+	parent, ok := parents[parts[0]]
+	if !ok {
+		parent, err = getParentPower(c, link)
+		if err != nil {
+			return nil, err
+		}
+		parents[parts[0]] = parent
+	}
+	if index >= len(parent.Redundancy) {
+		return nil, fmt.Errorf("redundancy %d not found in %s", index, parts[0])
+	}
+
+	redundancy := parent.Redundancy[index]
+	redundancy.SetClient(c)
+	return &redundancy, nil
+}
+
+// Assembly gets the Assembly for this power supply.
+func (powersupply *PowerSupply) Assembly() (*Assembly, error) {
+	if powersupply.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(powersupply.Client, powersupply.assembly)
+}
+
+// SupportsIndicatorLED reports whether the indicator LED of the power supply
+// can be controlled. This requires the service to have reported IndicatorLED
+// and, if it lists the writable properties, to include IndicatorLED there.
+func (powersupply *PowerSupply) SupportsIndicatorLED() bool {
+	if !powersupply.indicatorLEDReported {
+		return false
+	}
+	if powersupply.writeableProperties == nil {
+		return true
+	}
+
+	for _, property := range powersupply.writeableProperties {
+		if property == "IndicatorLED" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetIndicator sets the indicator LED of the power supply on the service. It
+// returns common.ErrUpdateNotSupported without a request if the LED cannot be
+// controlled, as reported by SupportsIndicatorLED.
+func (powersupply *PowerSupply) SetIndicator(led common.IndicatorLED) error {
+	if !powersupply.SupportsIndicatorLED() {
+		return fmt.Errorf("power supply %q indicator LED: %w", powersupply.MemberID, common.ErrUpdateNotSupported)
+	}
+
+	powersupply.IndicatorLED = led
+	return powersupply.Update()
+}
+
+// powerSupplyWritableFields are the PowerSupply properties that may be
+// changed through Update.
+var powerSupplyWritableFields = []string{
+	"IndicatorLED",
+}
+
 // Update commits updates to this object's properties to the running system.
+// If the service rejects the change, a *common.RedfishError is returned.
 func (powersupply *PowerSupply) Update() error {
+	var headers map[string]string
+	if powersupply.etag != "" {
+		headers = map[string]string{"If-Match": powersupply.etag}
+	}
+
+	return powersupply.update(headers)
+}
+
+// ForceUpdate commits updates like Update, but sends If-Match: * so the
+// changes are applied even if the resource was modified since it was read.
+func (powersupply *PowerSupply) ForceUpdate() error {
+	return powersupply.update(map[string]string{"If-Match": "*"})
+}
+
+// update commits updates to this object's properties, sending the custom
+// headers with the request.
+func (powersupply *PowerSupply) update(customHeaders map[string]string) error {
 	// Get a representation of the object's original state so we can find what
 	// to update.
 	original := new(PowerSupply)
@@ -504,20 +3418,308 @@ func (powersupply *PowerSupply) Update() error {
 		return err
 	}
 
-	readWriteFields := []string{
-		"IndicatorLED",
+	// A supply embedded in a Power resource is changed through the
+	// PowerSupplies array of its parent
+	entity := powersupply.Entity
+	uri := powersupply.ODataID
+	if strings.Contains(uri, "#") {
+		index, err := embeddedIndex(uri, "PowerSupplies", powersupply.MemberID)
+		if err != nil {
+			return err
+		}
+		uri = strings.SplitN(uri, "#", 2)[0]
+		entity.Client = &embeddedPatchClient{
+			Client:   powersupply.Client,
+			uri:      uri,
+			property: "PowerSupplies",
+			index:    index,
+		}
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(powersupply).Elem()
 
-	return powersupply.Entity.Update(originalElement, currentElement, readWriteFields)
+	err = entity.UpdateWithHeaders(originalElement, currentElement, powerSupplyWritableFields, customHeaders)
+	return common.ClassifyServiceError(uri, err)
+}
+
+// UpdateVerified commits updates like Update, then reads the power supply back
+// from the service to confirm the changes took effect. Some implementations
+// accept a PATCH but silently ignore it; in that case an error naming the
+// fields that were not applied is returned.
+func (powersupply *PowerSupply) UpdateVerified() error {
+	err := powersupply.Update()
+	if err != nil {
+		return err
+	}
+
+	current, err := powersupply.fetchCurrent()
+	if err != nil {
+		return fmt.Errorf("unable to verify power supply update: %w", err)
+	}
+
+	intended := reflect.ValueOf(powersupply).Elem()
+	reported := reflect.ValueOf(current).Elem()
+
+	var ignored []string
+	for _, field := range powerSupplyWritableFields {
+		if !reflect.DeepEqual(intended.FieldByName(field).Interface(), reported.FieldByName(field).Interface()) {
+			ignored = append(ignored, field)
+		}
+	}
+	if len(ignored) > 0 {
+		return fmt.Errorf("service did not apply changes to power supply %q: %s",
+			powersupply.MemberID, strings.Join(ignored, ", "))
+	}
+
+	return nil
+}
+
+// fetchCurrent retrieves the current state of this power supply from the
+// service.
+func (powersupply *PowerSupply) fetchCurrent() (*PowerSupply, error) {
+	if !strings.Contains(powersupply.ODataID, "#") {
+		var current PowerSupply
+		err := getJSON(powersupply.Client, powersupply.ODataID, &current)
+		if err != nil {
+			return nil, err
+		}
+		return &current, nil
+	}
+
+	power, err := getParentPower(powersupply.Client, powersupply.ODataID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		if (powersupply.MemberID != "" && supply.MemberID == powersupply.MemberID) ||
+			(powersupply.MemberID == "" && supply.ODataID == powersupply.ODataID) {
+			return supply, nil
+		}
+	}
+
+	return nil, fmt.Errorf("power supply %q not found in %s", powersupply.MemberID, power.ODataID)
+}
+
+// InputCurrentAmps returns the input current of the power supply. If the
+// service does not report InputCurrentAmps it is computed from
+// PowerInputWatts and LineInputVoltage. False is returned if neither is
+// possible.
+func (powersupply *PowerSupply) InputCurrentAmps() (float64, bool) {
+	if powersupply.inputCurrentAmps != nil {
+		return *powersupply.inputCurrentAmps, true
+	}
+
+	if powersupply.PowerInputWatts > 0 && powersupply.LineInputVoltage > 0 {
+		return powersupply.PowerInputWatts / powersupply.LineInputVoltage, true
+	}
+
+	return 0, false
+}
+
+// OutputCurrentAmps returns the output current of the power supply, if
+// reported by the service. No fallback is computed since the output voltage
+// of a supply is not part of the Power schema.
+func (powersupply *PowerSupply) OutputCurrentAmps() (float64, bool) {
+	if powersupply.outputCurrentAmps != nil {
+		return *powersupply.outputCurrentAmps, true
+	}
+
+	return 0, false
+}
+
+// manufactureDateLayouts are the date formats accepted for ManufactureDate.
+var manufactureDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"20060102",
+	"2006-01",
+}
+
+// parseManufactureDate parses a manufacture date in any of the accepted
+// layouts, returning the zero time if it cannot be parsed.
+func parseManufactureDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range manufactureDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
+
+// Age returns how long before now the power supply was manufactured. False is
+// returned if the service did not report a parseable manufacture date.
+func (powersupply *PowerSupply) Age(now time.Time) (time.Duration, bool) {
+	if powersupply.ManufactureDate.IsZero() {
+		return 0, false
+	}
+
+	return now.Sub(powersupply.ManufactureDate), true
+}
+
+// IsPresent reports whether the power supply is physically installed, that
+// is its Status.State is not Absent. A supply that does not report its state
+// is taken to be present.
+func (powersupply *PowerSupply) IsPresent() bool {
+	return !powersupply.IsAbsent()
+}
+
+// IsAbsent reports whether the service reports the power supply bay or slot
+// as empty, that is its Status.State is Absent.
+func (powersupply *PowerSupply) IsAbsent() bool {
+	return powersupply.Status.State == common.AbsentState
+}
+
+// Slot returns the LocationOrdinalValue of the PartLocation of the power
+// supply, such as 2 for the supply in slot 2, so a supply can be named by
+// where it is installed. False is returned if the service does not report
+// the part location.
+func (powersupply *PowerSupply) Slot() (int, bool) {
+	partLocation := powersupply.Location.PartLocation
+	if partLocation.LocationType == "" {
+		return 0, false
+	}
+
+	return partLocation.LocationOrdinalValue, true
+}
+
+// IsFailedPresent reports whether the power supply is installed and enabled
+// but in a critical health state, i.e. it needs to be replaced.
+func (powersupply *PowerSupply) IsFailedPresent() bool {
+	return powersupply.Status.State == common.EnabledState &&
+		powersupply.Status.Health == common.CriticalHealth
+}
+
+// MeasuredVoltage returns the measured line input voltage of the power
+// supply. False is returned if the service does not report it.
+func (powersupply *PowerSupply) MeasuredVoltage() (float64, bool) {
+	return powersupply.LineInputVoltage, powersupply.LineInputVoltage != 0
+}
+
+// NominalVoltage returns the nominal line input voltage of the power supply,
+// derived from LineInputVoltageType as described for NominalVolts.
+func (powersupply *PowerSupply) NominalVoltage() (float64, bool) {
+	return powersupply.LineInputVoltageType.NominalVolts()
+}
+
+// SupportsVoltage reports whether any of the input ranges of the power
+// supply of the given input type covers the line voltage. Ranges may be
+// reported with the bounds in either order, and a DC range is matched by
+// magnitude so that -48V is covered by a range of 40V to 60V. False is
+// returned if the service reports no input ranges.
+func (powersupply *PowerSupply) SupportsVoltage(volts float64, inputType InputType) bool {
+	for _, inputRange := range powersupply.InputRanges {
+		if inputRange.InputType != inputType {
+			continue
+		}
+
+		low := math.Min(inputRange.MinimumVoltage, inputRange.MaximumVoltage)
+		high := math.Max(inputRange.MinimumVoltage, inputRange.MaximumVoltage)
+		if volts >= low && volts <= high {
+			return true
+		}
+		if inputType == DCInputType && -volts >= low && -volts <= high {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActiveConditions returns the conditions the service reports as currently
+// affecting the power supply, giving the specific fault behind a Warning or
+// Critical health.
+func (powersupply *PowerSupply) ActiveConditions() []common.Condition {
+	return powersupply.Status.Conditions
+}
+
+// ApparentPowerVA returns the apparent input power of the power supply in
+// volt-amperes, computed from PowerInputWatts and PowerFactor. False is
+// returned if the input power is not reported or the power factor is not
+// between 0 and 1.
+func (powersupply *PowerSupply) ApparentPowerVA() (float64, bool) {
+	if powersupply.PowerInputWatts <= 0 ||
+		powersupply.PowerFactor <= 0 || powersupply.PowerFactor > 1 {
+		return 0, false
+	}
+
+	return powersupply.PowerInputWatts / powersupply.PowerFactor, true
+}
+
+// LoadPercent returns the measured output of the power supply as a percentage
+// of its rated PowerCapacityWatts. False is returned if the supply does not
+// report a positive capacity.
+func (powersupply *PowerSupply) LoadPercent() (float64, bool) {
+	return percentOf(powersupply.PowerOutputWatts, powersupply.PowerCapacityWatts)
+}
+
+// FirmwareOlderThan reports whether the firmware of the power supply is older
+// than the other version, as compared by FirmwareVersion.Compare. An error is
+// returned if the supply does not report its firmware version or the versions
+// cannot be compared.
+func (powersupply *PowerSupply) FirmwareOlderThan(other string) (bool, error) {
+	if powersupply.FirmwareVersion == "" {
+		return false, fmt.Errorf("power supply %s does not report its firmware version", powersupply.MemberID)
+	}
+
+	current, err := ParseFirmwareVersion(powersupply.FirmwareVersion)
+	if err != nil {
+		return false, err
+	}
+	wanted, err := ParseFirmwareVersion(other)
+	if err != nil {
+		return false, err
+	}
+
+	order, err := current.Compare(wanted)
+	if err != nil {
+		return false, err
+	}
+	return order < 0, nil
+}
+
+// String returns a one line description of the power supply for logging,
+// such as "PSU[1] AC 750W out, 92% eff, OK". Readings that are not reported
+// are left out.
+func (powersupply *PowerSupply) String() string {
+	buf := make([]byte, 0, 48)
+	buf = append(buf, "PSU["...)
+	buf = append(buf, powersupply.MemberID...)
+	buf = append(buf, ']')
+
+	if powersupply.PowerSupplyType != "" && powersupply.PowerSupplyType != UnknownPowerSupplyType {
+		buf = append(buf, ' ')
+		buf = append(buf, powersupply.PowerSupplyType...)
+	}
+	if powersupply.PowerOutputWatts > 0 {
+		buf = append(buf, ' ')
+		buf = strconv.AppendFloat(buf, powersupply.PowerOutputWatts, 'f', 0, 64)
+		buf = append(buf, "W out"...)
+	}
+	if powersupply.EfficiencyPercent > 0 {
+		buf = append(buf, ", "...)
+		buf = strconv.AppendFloat(buf, powersupply.EfficiencyPercent, 'f', 0, 64)
+		buf = append(buf, "% eff"...)
+	}
+
+	buf = append(buf, ", "...)
+	buf = appendStatus(buf, powersupply.Status)
+	return string(buf)
 }
 
 // Voltage is a voltage representation.
 type Voltage struct {
 	common.Entity
 
+	// DataSourceURI shall contain a URI to the Sensor resource that provides
+	// the data for this voltage, if the voltage is an excerpt of one.
+	DataSourceURI string `json:"DataSourceUri"`
 	// LowerThresholdCritical shall indicate
 	// the present reading is below the normal range but is not yet fatal.
 	// Units shall use the same units as the related ReadingVolts property.
@@ -544,7 +3746,7 @@ type Voltage struct {
 	// PhysicalContext shall be a description
 	// of the affected device or region within the chassis to which this
 	// voltage measurement applies.
-	PhysicalContext string
+	PhysicalContext common.PhysicalContext
 	// ReadingVolts shall be the present
 	// reading of the voltage sensor's reading.
 	ReadingVolts float64
@@ -567,6 +3769,11 @@ type Voltage struct {
 	// the present reading is above the normal range but is not critical.
 	// Units shall use the same units as the related ReadingVolts property.
 	UpperThresholdNonCritical float64
+	// reportedThresholds records which thresholds the service reported, so
+	// that a threshold of 0 can be told apart from a missing one.
+	reportedThresholds thresholdSet
+	// readingReported records whether the service reported the reading.
+	readingReported bool
 }
 
 // UnmarshalJSON unmarshals a Voltage object from the raw JSON.
@@ -574,12 +3781,22 @@ func (voltage *Voltage) UnmarshalJSON(b []byte) error { // nolint:dupl
 	type temp Voltage
 	type t1 struct {
 		temp
+		PhysicalContext json.RawMessage
+		ReadingVolts    *float64
+		// Reading and ReadingUnits are used by sensor excerpts in newer
+		// versions of the schema in place of ReadingVolts.
+		Reading      *float64
+		ReadingUnits string
 	}
 	var t t1
 
 	err := json.Unmarshal(b, &t)
 	if err != nil {
-		// See if we need to handle converting MemberID
+		if !isMemberIDTypeError(err) {
+			return err
+		}
+
+		// Handle converting a numeric MemberID
 		var t2 struct {
 			t1
 			MemberID int `json:"MemberId"`
@@ -598,6 +3815,183 @@ func (voltage *Voltage) UnmarshalJSON(b []byte) error { // nolint:dupl
 
 	// Extract the links to other entities for later
 	*voltage = Voltage(t.temp)
+	voltage.PhysicalContext = decodePhysicalContext(t.PhysicalContext)
+
+	switch {
+	case t.ReadingVolts != nil:
+		voltage.ReadingVolts = *t.ReadingVolts
+		voltage.readingReported = true
+	case t.Reading != nil && t.ReadingUnits == "Volts":
+		voltage.ReadingVolts = *t.Reading
+		voltage.readingReported = true
+	}
+
+	var reported struct {
+		LowerThresholdCritical    *float64
+		LowerThresholdFatal       *float64
+		LowerThresholdNonCritical *float64
+		UpperThresholdCritical    *float64
+		UpperThresholdFatal       *float64
+		UpperThresholdNonCritical *float64
+	}
+	if json.Unmarshal(b, &reported) == nil {
+		voltage.reportedThresholds = reportedThresholds(
+			reported.LowerThresholdNonCritical, reported.LowerThresholdCritical, reported.LowerThresholdFatal,
+			reported.UpperThresholdNonCritical, reported.UpperThresholdCritical, reported.UpperThresholdFatal)
+	}
 
 	return nil
 }
+
+// MarshalJSON marshals the voltage into its Redfish form. Thresholds that
+// were neither reported by the service nor set are left out, so that they
+// stay unreported when the result is decoded again.
+func (voltage *Voltage) MarshalJSON() ([]byte, error) {
+	type temp Voltage
+	known := voltage.knownThresholds()
+	t := struct {
+		temp
+		DataSourceURI             string   `json:"DataSourceUri,omitempty"`
+		LowerThresholdCritical    *float64 `json:",omitempty"`
+		LowerThresholdFatal       *float64 `json:",omitempty"`
+		LowerThresholdNonCritical *float64 `json:",omitempty"`
+		UpperThresholdCritical    *float64 `json:",omitempty"`
+		UpperThresholdFatal       *float64 `json:",omitempty"`
+		UpperThresholdNonCritical *float64 `json:",omitempty"`
+	}{
+		temp:                      temp(*voltage),
+		DataSourceURI:             voltage.DataSourceURI,
+		LowerThresholdCritical:    knownThreshold(known, lowerCriticalThreshold, voltage.LowerThresholdCritical),
+		LowerThresholdFatal:       knownThreshold(known, lowerFatalThreshold, voltage.LowerThresholdFatal),
+		LowerThresholdNonCritical: knownThreshold(known, lowerNonCriticalThreshold, voltage.LowerThresholdNonCritical),
+		UpperThresholdCritical:    knownThreshold(known, upperCriticalThreshold, voltage.UpperThresholdCritical),
+		UpperThresholdFatal:       knownThreshold(known, upperFatalThreshold, voltage.UpperThresholdFatal),
+		UpperThresholdNonCritical: knownThreshold(known, upperNonCriticalThreshold, voltage.UpperThresholdNonCritical),
+	}
+
+	return json.Marshal(t)
+}
+
+// knownThreshold returns a pointer to value if threshold is in known, and
+// nil otherwise.
+func knownThreshold(known, threshold thresholdSet, value float64) *float64 {
+	if known&threshold == 0 {
+		return nil
+	}
+	return &value
+}
+
+// thresholdSet is a set of the thresholds of a voltage sensor.
+type thresholdSet uint8
+
+const (
+	lowerNonCriticalThreshold thresholdSet = 1 << iota
+	lowerCriticalThreshold
+	lowerFatalThreshold
+	upperNonCriticalThreshold
+	upperCriticalThreshold
+	upperFatalThreshold
+)
+
+// reportedThresholds returns the set of thresholds that are not nil.
+func reportedThresholds(lowerNonCritical, lowerCritical, lowerFatal, upperNonCritical, upperCritical, upperFatal *float64) thresholdSet {
+	var set thresholdSet
+	for i, threshold := range []*float64{lowerNonCritical, lowerCritical, lowerFatal, upperNonCritical, upperCritical, upperFatal} {
+		if threshold != nil {
+			set |= 1 << uint(i)
+		}
+	}
+
+	return set
+}
+
+// knownThresholds returns the thresholds that the service reported, along
+// with any that are set to a value other than 0.
+func (voltage *Voltage) knownThresholds() thresholdSet {
+	known := voltage.reportedThresholds
+	for _, threshold := range []struct {
+		set   thresholdSet
+		value float64
+	}{
+		{lowerNonCriticalThreshold, voltage.LowerThresholdNonCritical},
+		{lowerCriticalThreshold, voltage.LowerThresholdCritical},
+		{lowerFatalThreshold, voltage.LowerThresholdFatal},
+		{upperNonCriticalThreshold, voltage.UpperThresholdNonCritical},
+		{upperCriticalThreshold, voltage.UpperThresholdCritical},
+		{upperFatalThreshold, voltage.UpperThresholdFatal},
+	} {
+		if threshold.value != 0 {
+			known |= threshold.set
+		}
+	}
+
+	return known
+}
+
+// ThresholdState returns the most severe threshold that ReadingVolts is at or
+// beyond. Thresholds the service did not report are skipped; a threshold of
+// 0 is only used if the service reported it. For a Voltage that was not
+// decoded from the service, thresholds of 0 are taken as unset.
+func (voltage *Voltage) ThresholdState() VoltageThresholdState {
+	reported := voltage.knownThresholds()
+	reading := voltage.ReadingVolts
+	switch {
+	case reported&lowerFatalThreshold != 0 && reading <= voltage.LowerThresholdFatal:
+		return LowerFatalVoltageThresholdState
+	case reported&upperFatalThreshold != 0 && reading >= voltage.UpperThresholdFatal:
+		return UpperFatalVoltageThresholdState
+	case reported&lowerCriticalThreshold != 0 && reading <= voltage.LowerThresholdCritical:
+		return LowerCriticalVoltageThresholdState
+	case reported&upperCriticalThreshold != 0 && reading >= voltage.UpperThresholdCritical:
+		return UpperCriticalVoltageThresholdState
+	case reported&lowerNonCriticalThreshold != 0 && reading <= voltage.LowerThresholdNonCritical:
+		return LowerNonCriticalVoltageThresholdState
+	case reported&upperNonCriticalThreshold != 0 && reading >= voltage.UpperThresholdNonCritical:
+		return UpperNonCriticalVoltageThresholdState
+	}
+
+	return NormalVoltageThresholdState
+}
+
+// IsReadingPlausible reports whether ReadingVolts lies within the
+// MinReadingRange and MaxReadingRange declared by the service. True is
+// returned if no range is declared.
+func (voltage *Voltage) IsReadingPlausible() bool {
+	return readingInRange(voltage.ReadingVolts, voltage.MinReadingRange, voltage.MaxReadingRange)
+}
+
+// VoltageThresholds holds the thresholds of a Voltage converted to another
+// unit. Thresholds the service does not report are zero, as in Voltage.
+type VoltageThresholds struct {
+	LowerNonCritical float64
+	LowerCritical    float64
+	LowerFatal       float64
+	UpperNonCritical float64
+	UpperCritical    float64
+	UpperFatal       float64
+}
+
+// voltsToMillivolts converts volts to millivolts, rounded to the nearest
+// microvolt so that 3.3V is 3300mV rather than 3299.9999999999995mV.
+func voltsToMillivolts(volts float64) float64 {
+	return math.Round(volts*1e6) / 1e3
+}
+
+// ReadingMillivolts returns ReadingVolts in millivolts. ReadingVolts remains
+// the reading as reported by the service.
+func (voltage *Voltage) ReadingMillivolts() float64 {
+	return voltsToMillivolts(voltage.ReadingVolts)
+}
+
+// ThresholdsMillivolts returns the thresholds of the voltage in millivolts,
+// converted the same way as ReadingMillivolts.
+func (voltage *Voltage) ThresholdsMillivolts() VoltageThresholds {
+	return VoltageThresholds{
+		LowerNonCritical: voltsToMillivolts(voltage.LowerThresholdNonCritical),
+		LowerCritical:    voltsToMillivolts(voltage.LowerThresholdCritical),
+		LowerFatal:       voltsToMillivolts(voltage.LowerThresholdFatal),
+		UpperNonCritical: voltsToMillivolts(voltage.UpperThresholdNonCritical),
+		UpperCritical:    voltsToMillivolts(voltage.UpperThresholdCritical),
+		UpperFatal:       voltsToMillivolts(voltage.UpperThresholdFatal),
+	}
+}
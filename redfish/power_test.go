@@ -5,9 +5,18 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ciferlu1024/gofish/common"
 )
@@ -559,3 +568,3911 @@ func TestNonconformingPower(t *testing.T) {
 		t.Errorf("Expected first Voltage MemberID to be '218': %s", voltage.MemberID)
 	}
 }
+
+// TestGetPowerTypeMismatch tests that a misrouted response is rejected.
+func TestGetPowerTypeMismatch(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(thermalBody), // nolint
+			},
+		},
+	}
+
+	_, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err == nil {
+		t.Fatal("Expected an error when decoding a Thermal body as Power")
+	}
+
+	var parseErr *common.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a ParseError, got: %v", err)
+	}
+
+	var typeErr *common.ResourceTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected a ResourceTypeError, got: %v", err)
+	}
+
+	if typeErr.ODataType != "#Thermal.v1_0_0.Thermal" {
+		t.Errorf("Unexpected reported type: %s", typeErr.ODataType)
+	}
+}
+
+var supplyLoadBody = `{
+		"@odata.type": "#Power.v1_5_3.Power",
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerSupplies": [
+			{
+				"MemberId": "0",
+				"PowerCapacityWatts": 1000,
+				"PowerOutputWatts": 250
+			},
+			{
+				"MemberId": "1",
+				"PowerCapacityWatts": 800,
+				"PowerOutputWatts": 400
+			},
+			{
+				"MemberId": "2",
+				"PowerOutputWatts": 100
+			}
+		]
+	}`
+
+// TestPowerSupplyLoadPercent tests the per-supply load calculations.
+func TestPowerSupplyLoadPercent(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(supplyLoadBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	load, ok := result.PowerSupplies[0].LoadPercent()
+	if !ok || load != 25 {
+		t.Errorf("Expected 25%% load, got %f (%t)", load, ok)
+	}
+
+	if _, ok := result.PowerSupplies[2].LoadPercent(); ok {
+		t.Error("Supply without capacity should not report a load")
+	}
+
+	distribution := result.SupplyLoadDistribution()
+	if len(distribution) != 2 {
+		t.Errorf("Expected 2 supplies in distribution, got %v", distribution)
+	}
+
+	if distribution["1"] != 50 {
+		t.Errorf("Expected supply 1 at 50%%, got %f", distribution["1"])
+	}
+}
+
+var discoveryServiceRootBody = `{
+		"@odata.id": "/redfish/v1/",
+		"Chassis": {
+			"@odata.id": "/redfish/v1/Chassis"
+		}
+	}`
+
+var discoveryChassisCollectionBody = `{
+		"@odata.id": "/redfish/v1/Chassis",
+		"Name": "Chassis Collection",
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/Blade1"
+			},
+			{
+				"@odata.id": "/redfish/v1/Chassis/1"
+			}
+		],
+		"Members@odata.count": 2
+	}`
+
+var discoveryChassisBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1/Power"
+		}
+	}`
+
+// TestDiscoverPower tests walking from the service root to a Power resource.
+func TestDiscoverPower(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(discoveryServiceRootBody),       // nolint
+				getCall(discoveryChassisCollectionBody), // nolint
+				getCall(discoveryChassisBody),           // nolint
+				getCall(supplyLoadBody),                 // nolint
+			},
+		},
+	}
+
+	power, err := DiscoverPower(testClient, "1")
+	if err != nil {
+		t.Fatalf("Error discovering power: %s", err)
+	}
+
+	if power.ODataID != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected power resource: %s", power.ODataID)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[2].URL != "/redfish/v1/Chassis/1" {
+		t.Errorf("Expected chassis 1 to be fetched, got %s", calls[2].URL)
+	}
+}
+
+// TestDiscoverPowerUnknownChassis tests discovery of a chassis that does not exist.
+func TestDiscoverPowerUnknownChassis(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(discoveryServiceRootBody),       // nolint
+				getCall(discoveryChassisCollectionBody), // nolint
+			},
+		},
+	}
+
+	_, err := DiscoverPower(testClient, "2")
+	if err == nil || !strings.Contains(err.Error(), `chassis "2" not found`) {
+		t.Errorf("Expected chassis not found error, got: %v", err)
+	}
+}
+
+// TestHistogramByLoad tests bucketing supplies across Power resources.
+func TestHistogramByLoad(t *testing.T) {
+	var first, second Power
+	err := json.NewDecoder(strings.NewReader(supplyLoadBody)).Decode(&first)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	err = json.NewDecoder(strings.NewReader(supplyLoadBody)).Decode(&second)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	second.PowerSupplies[1].Status.State = common.AbsentState
+
+	histogram := HistogramByLoad([]*Power{&first, &second, nil}, 10)
+
+	if histogram[2] != 2 {
+		t.Errorf("Expected 2 supplies in the 20-30%% bucket, got %d", histogram[2])
+	}
+
+	if histogram[5] != 1 {
+		t.Errorf("Expected 1 supply in the 50-60%% bucket, got %d", histogram[5])
+	}
+
+	if len(histogram) != 2 {
+		t.Errorf("Unexpected buckets: %v", histogram)
+	}
+}
+
+// TestNormalizePhysicalContext tests mapping vendor physical context strings.
+func TestNormalizePhysicalContext(t *testing.T) {
+	tests := map[string]common.PhysicalContext{
+		"CPU":            common.CPUPhysicalContext,
+		"Cpu":            common.CPUPhysicalContext,
+		"Processor":      common.CPUPhysicalContext,
+		"system_board":   common.SystemBoardPhysicalContext,
+		"Power Supply":   common.PowerSupplyPhysicalContext,
+		"VendorBoardXYZ": "VendorBoardXYZ",
+		"":               "",
+	}
+
+	logger := &warningLogger{}
+	common.SetLogger(logger)
+	defer common.SetLogger(nil)
+
+	for input, expected := range tests {
+		if result := NormalizePhysicalContext(input); result != expected {
+			t.Errorf("Expected %q to normalize to %q, got %q", input, expected, result)
+		}
+	}
+
+	if len(logger.warnings) != 1 {
+		t.Errorf("Expected a warning for the unrecognized context, got %v", logger.warnings)
+	}
+}
+
+// warningLogger keeps the warnings it receives.
+type warningLogger struct {
+	warnings []string
+}
+
+func (l *warningLogger) Debugf(string, ...interface{}) {}
+func (l *warningLogger) Errorf(string, ...interface{}) {}
+
+func (l *warningLogger) Warnf(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+var mixedContextPowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [
+			{
+				"MemberId": "0",
+				"PhysicalContext": "Processor"
+			}
+		],
+		"Voltages": [
+			{
+				"MemberId": "0",
+				"PhysicalContext": "cpu"
+			},
+			{
+				"MemberId": "1",
+				"PhysicalContext": "SystemBoard"
+			}
+		]
+	}`
+
+// TestPowerPhysicalContexts tests that contexts are normalized during decoding.
+func TestPowerPhysicalContexts(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(mixedContextPowerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	contexts := result.PhysicalContexts()
+	if len(contexts) != 2 {
+		t.Fatalf("Expected 2 distinct contexts, got %v", contexts)
+	}
+
+	if contexts[0] != common.CPUPhysicalContext || contexts[1] != common.SystemBoardPhysicalContext {
+		t.Errorf("Unexpected contexts: %v", contexts)
+	}
+}
+
+// TestPowerBatchApply tests sending changes as a single batch request.
+func TestPowerBatchApply(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				getCall(`{"responses": [
+					{"id": "1", "status": 400, "body": {"error": {"code": "Base.1.0.PropertyValueNotInList"}}},
+					{"id": "0", "status": 200}
+				]}`),
+			},
+		},
+	}
+
+	var result Power
+	result.SetClient(testClient)
+
+	results, err := result.BatchApply([]BatchChange{
+		{URI: "/redfish/v1/Chassis/1/Power", Payload: map[string]interface{}{"IndicatorLED": "Lit"}},
+		{URI: "/redfish/v1/Chassis/2/Power", Payload: map[string]interface{}{"IndicatorLED": "Red"}},
+	})
+	if err != nil {
+		t.Fatalf("Error applying batch: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/$batch" {
+		t.Errorf("Expected a single batch call, captured: %v", calls)
+	}
+
+	if results[0].StatusCode != 200 || results[0].Err != nil {
+		t.Errorf("Unexpected result for first change: %+v", results[0])
+	}
+
+	if results[1].StatusCode != 400 || results[1].Err == nil {
+		t.Errorf("Expected second change to fail: %+v", results[1])
+	}
+
+	// A resource without a client fails rather than panicking
+	if _, err := (&Power{}).BatchApply([]BatchChange{{URI: "/redfish/v1/Chassis/1/Power"}}); err == nil {
+		t.Error("Expected an error without a client")
+	}
+}
+
+// TestPowerBatchApplyFallback tests falling back to individual requests.
+func TestPowerBatchApplyFallback(t *testing.T) {
+	notFound := getCall("")
+	notFound.StatusCode = http.StatusNotFound
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {notFound},
+		},
+	}
+
+	var result Power
+	result.SetClient(testClient)
+
+	results, err := result.BatchApply([]BatchChange{
+		{URI: "/redfish/v1/Chassis/1/Power", Payload: map[string]interface{}{"IndicatorLED": "Lit"}},
+		{URI: "/redfish/v1/Chassis/2/Power", Payload: map[string]interface{}{"IndicatorLED": "Off"}},
+	})
+	if err != nil {
+		t.Fatalf("Error applying batch: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 3 {
+		t.Fatalf("Expected one batch attempt and two PATCH calls, captured: %v", calls)
+	}
+
+	if calls[1].Action != http.MethodPatch || calls[2].URL != "/redfish/v1/Chassis/2/Power" {
+		t.Errorf("Unexpected fallback calls: %v", calls[1:])
+	}
+
+	if len(results) != 2 || results[0].Err != nil || results[1].Err != nil {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+// powerLimitBody returns a Power body with the given power limit.
+func powerLimitBody(limit int) string {
+	return fmt.Sprintf(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+				"MemberId": "0",
+				"PowerLimit": {
+					"LimitInWatts": %d
+				}
+			}
+		]
+	}`, limit)
+}
+
+// TestPowerControlWaitForLimit tests polling until a power limit is applied.
+func TestPowerControlWaitForLimit(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(powerLimitBody(500)),
+				getCall(powerLimitBody(750)),
+			},
+		},
+	}
+
+	control := PowerControl{MemberID: "0"}
+	control.ODataID = "/redfish/v1/Chassis/1/Power#/PowerControl/0"
+	control.SetClient(testClient)
+
+	err := control.WaitForLimit(context.Background(), 750, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Error waiting for limit: %s", err)
+	}
+
+	if control.PowerLimit.LimitInWatts != 750 {
+		t.Errorf("Expected limit to be updated, got %f", control.PowerLimit.LimitInWatts)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestPowerControlWaitForLimitCancelled tests the error returned on cancellation.
+func TestPowerControlWaitForLimitCancelled(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(powerLimitBody(500)),
+			},
+		},
+	}
+
+	control := PowerControl{MemberID: "0"}
+	control.ODataID = "/redfish/v1/Chassis/1/Power#/PowerControl/0"
+	control.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := control.WaitForLimit(ctx, 750, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected cancellation error, got: %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "last observed 500 W") {
+		t.Errorf("Expected last observed value in error: %s", err)
+	}
+}
+
+var manufactureDatePowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{
+				"MemberId": "0",
+				"ManufactureDate": "2020-03-01T00:00:00Z"
+			},
+			{
+				"MemberId": "1",
+				"Oem": {
+					"Vendor": {
+						"ManufactureDate": "03/01/2021"
+					}
+				}
+			},
+			{
+				"MemberId": "2",
+				"ManufactureDate": "sometime last year"
+			}
+		]
+	}`
+
+// TestPowerSupplyAge tests parsing of supply manufacture dates.
+func TestPowerSupplyAge(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(manufactureDatePowerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	age, ok := result.PowerSupplies[0].Age(now)
+	if !ok || age != 730*24*time.Hour {
+		t.Errorf("Unexpected age for standard date: %s (%t)", age, ok)
+	}
+
+	age, ok = result.PowerSupplies[1].Age(now)
+	if !ok || age != 365*24*time.Hour {
+		t.Errorf("Unexpected age for OEM date: %s (%t)", age, ok)
+	}
+
+	if _, ok := result.PowerSupplies[2].Age(now); ok {
+		t.Error("Unparseable date should not report an age")
+	}
+}
+
+// indicatorPowerBody returns a Power body with a supply in the given LED state.
+func indicatorPowerBody(led common.IndicatorLED) string {
+	return fmt.Sprintf(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+				"MemberId": "0",
+				"IndicatorLED": %q
+			}
+		]
+	}`, led)
+}
+
+// TestPowerSupplyUpdateVerified tests reading back a power supply update.
+func TestPowerSupplyUpdateVerified(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(indicatorPowerBody(common.OffIndicatorLED))).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(indicatorPowerBody(common.LitIndicatorLED)),
+				getCall(indicatorPowerBody(common.OffIndicatorLED)),
+			},
+		},
+	}
+
+	supply := &result.PowerSupplies[0]
+	supply.SetClient(testClient)
+	supply.IndicatorLED = common.LitIndicatorLED
+
+	err = supply.UpdateVerified()
+	if err != nil {
+		t.Errorf("Error making verified update: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Expected PATCH followed by GET of the parent Power, captured: %v", calls)
+	}
+
+	// The second read back returns the LED still off
+	err = supply.UpdateVerified()
+	if err == nil || !strings.Contains(err.Error(), "IndicatorLED") {
+		t.Errorf("Expected ignored IndicatorLED change to be reported, got: %v", err)
+	}
+}
+
+// TestPowerControlAllocation tests checking the allocation arithmetic.
+func TestPowerControlAllocation(t *testing.T) {
+	tests := []struct {
+		body       string
+		consistent bool
+		headroom   float64
+	}{
+		{`{"PowerCapacityWatts": 1000, "PowerAllocatedWatts": 600, "PowerAvailableWatts": 400}`, true, 400},
+		{`{"PowerCapacityWatts": 1000, "PowerAllocatedWatts": 333.3, "PowerAvailableWatts": 667}`, true, 666.7},
+		{`{"PowerCapacityWatts": 1000, "PowerAllocatedWatts": 600, "PowerAvailableWatts": 1000}`, false, 400},
+		{`{"PowerCapacityWatts": 1000, "PowerAllocatedWatts": 1200, "PowerAvailableWatts": -200}`, true, 0},
+		{`{"PowerAvailableWatts": 250}`, false, 250},
+		{`{}`, true, 0},
+	}
+
+	for _, test := range tests {
+		var control PowerControl
+		if err := json.Unmarshal([]byte(test.body), &control); err != nil {
+			t.Fatalf("Error decoding %s: %s", test.body, err)
+		}
+		if control.AllocationConsistent() != test.consistent {
+			t.Errorf("%s: expected AllocationConsistent %t", test.body, test.consistent)
+		}
+		if headroom := control.HeadroomWatts(); math.Abs(headroom-test.headroom) > 1e-9 {
+			t.Errorf("%s: expected headroom %g, got %g", test.body, test.headroom, headroom)
+		}
+	}
+}
+
+// TestPowerControlIsConsumptionPlausible tests checking consumption against the reading range.
+func TestPowerControlIsConsumptionPlausible(t *testing.T) {
+	tests := []struct {
+		control  PowerControl
+		expected bool
+	}{
+		{PowerControl{PowerConsumedWatts: 350}, true},
+		{PowerControl{PowerConsumedWatts: 350, ReadingRangeMin: 0, ReadingRangeMax: 2000}, true},
+		{PowerControl{PowerConsumedWatts: 65535, ReadingRangeMin: 0, ReadingRangeMax: 2000}, false},
+		{PowerControl{PowerConsumedWatts: 0, ReadingRangeMin: 50, ReadingRangeMax: 2000}, false},
+	}
+
+	for _, test := range tests {
+		if result := test.control.IsConsumptionPlausible(); result != test.expected {
+			t.Errorf("Expected %t for %f in [%f, %f]", test.expected, test.control.PowerConsumedWatts,
+				test.control.ReadingRangeMin, test.control.ReadingRangeMax)
+		}
+	}
+
+	voltage := Voltage{ReadingVolts: 14, MinReadingRange: 0, MaxReadingRange: 13}
+	if voltage.IsReadingPlausible() {
+		t.Error("Expected voltage outside its reading range to be implausible")
+	}
+}
+
+// TestPowerControlConsumedWattsExcerpt tests decoding PowerConsumedWatts
+// given as an excerpt of a Sensor.
+func TestPowerControlConsumedWattsExcerpt(t *testing.T) {
+	var control PowerControl
+	err := json.Unmarshal([]byte(`{
+		"MemberId": "0",
+		"PowerConsumedWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage",
+			"Reading": 344
+		}
+	}`), &control)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if control.PowerConsumedWatts != 344 || !control.ConsumedWatts().Present {
+		t.Errorf("Invalid PowerConsumedWatts: %g", control.PowerConsumedWatts)
+	}
+	excerpt := control.PowerConsumedWattsExcerpt
+	if excerpt == nil || excerpt.DataSourceURI != "/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage" {
+		t.Fatalf("Invalid excerpt: %+v", excerpt)
+	}
+
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage", sensorBody)
+	control.SetClient(c)
+	sensor, err := control.ConsumedWattsSensor()
+	if err != nil || sensor.ID != "PS1InputVoltage" {
+		t.Errorf("Invalid sensor: %+v: %v", sensor, err)
+	}
+
+	b, err := json.Marshal(&control)
+	if err != nil {
+		t.Fatalf("Error marshaling: %s", err)
+	}
+	var again PowerControl
+	if err := json.Unmarshal(b, &again); err != nil || again.PowerConsumedWattsExcerpt == nil ||
+		again.PowerConsumedWattsExcerpt.DataSourceURI != excerpt.DataSourceURI || again.PowerConsumedWatts != 344 {
+		t.Errorf("Expected the excerpt to be kept, got %s: %v", b, err)
+	}
+
+	// The plain number form has no sensor
+	var plain PowerControl
+	if err := json.Unmarshal([]byte(`{"MemberId": "0", "PowerConsumedWatts": "120.5"}`), &plain); err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	if sensor, err := plain.ConsumedWattsSensor(); plain.PowerConsumedWatts != 120.5 || sensor != nil || err != nil {
+		t.Errorf("Invalid plain PowerConsumedWatts %g: %v", plain.PowerConsumedWatts, err)
+	}
+}
+
+// TestPowerControlUnmarshalError tests that errors unrelated to MemberId are
+// returned without retrying the numeric MemberId decode.
+func TestPowerControlUnmarshalError(t *testing.T) {
+	var control PowerControl
+	err := json.Unmarshal([]byte(`{"MemberId": "0", "PowerLimit": {"LimitInWatts": "high"}}`), &control)
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field != "PowerLimit.LimitInWatts" {
+		t.Errorf("Expected LimitInWatts type error, got: %v", err)
+	}
+
+	var voltage Voltage
+	err = json.Unmarshal([]byte(`{"MemberId": 218, "ReadingVolts": 1.2}`), &voltage)
+	if err != nil || voltage.MemberID != "218" {
+		t.Errorf("Expected numeric MemberId to be converted, got %q: %v", voltage.MemberID, err)
+	}
+}
+
+// TestPowerFlatReadings tests exporting readings as a flat map.
+func TestPowerFlatReadings(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"PowerControl": [{"PowerConsumedWatts": 344, "PowerMetrics": {"IntervalInMin": 5}}],
+		"PowerSupplies": [{"MemberId": "PSU1", "PowerOutputWatts": 172}],
+		"Voltages": [{"MemberId": "VRM1", "ReadingVolts": 1.8}]
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	readings := result.FlatReadings()
+	expected := map[string]float64{
+		"powercontrol.0.consumed_watts": 344,
+		"powercontrol.0.interval_min":   5,
+		"powersupply.PSU1.output_watts": 172,
+		"voltage.VRM1.reading_volts":    1.8,
+	}
+
+	if len(readings) != len(expected) {
+		t.Errorf("Expected only the reported readings, got %v", readings)
+	}
+	for key, value := range expected {
+		if readings[key] != value {
+			t.Errorf("Expected %s to be %f, got %f", key, value, readings[key])
+		}
+	}
+}
+
+// TestPowerFlatReadingsSparse tests that readings the service did not report
+// are left out, while reported zeros are kept.
+func TestPowerFlatReadingsSparse(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"PowerControl": [{
+			"MemberId": "0",
+			"PowerConsumedWatts": 0,
+			"PowerLimit": {"LimitInWatts": null},
+			"PowerMetrics": {"MinConsumedWatts": 0}
+		}],
+		"PowerSupplies": [{"MemberId": "PSU1", "PowerInputWatts": 0}, {"MemberId": "PSU2"}],
+		"Voltages": [{"MemberId": "VRM1"}, {"MemberId": "VRM2", "ReadingVolts": 0}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	readings := result.FlatReadings()
+	expected := map[string]float64{
+		"powercontrol.0.consumed_watts":     0,
+		"powercontrol.0.min_consumed_watts": 0,
+		"powersupply.PSU1.input_watts":      0,
+		"voltage.VRM2.reading_volts":        0,
+	}
+	if !reflect.DeepEqual(readings, expected) {
+		t.Errorf("Expected %v, got %v", expected, readings)
+	}
+
+	// Members built by hand have no record of what was reported, so values
+	// that are set are included
+	hand := &Power{PowerSupplies: []PowerSupply{{MemberID: "PSU1", PowerCapacityWatts: 800}}}
+	if readings := hand.FlatReadings(); len(readings) != 1 || readings["powersupply.PSU1.capacity_watts"] != 800 {
+		t.Errorf("Invalid readings of a hand built resource: %v", readings)
+	}
+}
+
+// TestPowerSupplyCurrent tests reading and computing supply currents.
+func TestPowerSupplyCurrent(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"PowerSupplies": [
+			{"MemberId": "0", "InputCurrentAmps": 2.5, "OutputCurrentAmps": 0},
+			{"MemberId": "1", "PowerInputWatts": 480, "LineInputVoltage": 240},
+			{"MemberId": "2", "PowerInputWatts": 480}
+		]
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if amps, ok := result.PowerSupplies[0].InputCurrentAmps(); !ok || amps != 2.5 {
+		t.Errorf("Unexpected reported input current: %f (%t)", amps, ok)
+	}
+
+	if amps, ok := result.PowerSupplies[0].OutputCurrentAmps(); !ok || amps != 0 {
+		t.Errorf("Expected reported zero output current: %f (%t)", amps, ok)
+	}
+
+	if amps, ok := result.PowerSupplies[1].InputCurrentAmps(); !ok || amps != 2 {
+		t.Errorf("Unexpected computed input current: %f (%t)", amps, ok)
+	}
+
+	if _, ok := result.PowerSupplies[1].OutputCurrentAmps(); ok {
+		t.Error("Output current should not be reported when absent")
+	}
+
+	if _, ok := result.PowerSupplies[2].InputCurrentAmps(); ok {
+		t.Error("Input current should not be computed without a line voltage")
+	}
+}
+
+// failingClient is a client whose GET requests fail without a response.
+type failingClient struct {
+	common.TestClient
+}
+
+// Get fails with a connection error.
+func (c *failingClient) Get(url string) (*http.Response, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+// TestGetPowerErrorTypes tests the classification of GetPower failures.
+func TestGetPowerErrorTypes(t *testing.T) {
+	_, err := GetPower(&failingClient{}, "/redfish/v1/Chassis/1/Power")
+	var transportErr *common.TransportError
+	if !errors.As(err, &transportErr) {
+		t.Errorf("Expected a TransportError, got: %v", err)
+	}
+
+	unavailable := getCall(`{"error": {"code": "Base.1.0.ServiceTemporarilyUnavailable"}}`)
+	unavailable.StatusCode = http.StatusServiceUnavailable
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				unavailable,
+				getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "PowerSupplies": [`),
+			},
+		},
+	}
+
+	_, err = GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	var redfishErr *common.RedfishError
+	if !errors.As(err, &redfishErr) || redfishErr.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("Expected a RedfishError with status 503, got: %v", err)
+	}
+
+	_, err = GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	var parseErr *common.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a ParseError, got: %v", err)
+	}
+}
+
+// TestListReferencedPowersErrorTypes tests that collection failures are typed.
+func TestListReferencedPowersErrorTypes(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(`{"Members": [{"@odata.id": "/redfish/v1/Chassis/1/Power"}], "Members@odata.count": 1}`),
+				getCall(thermalBody),
+			},
+		},
+	}
+
+	_, err := ListReferencedPowers(testClient, "/redfish/v1/Powers")
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) {
+		t.Fatalf("Expected a CollectionError, got: %v", err)
+	}
+
+	var parseErr *common.ParseError
+	if !errors.As(collectionErr.Failures["/redfish/v1/Chassis/1/Power"], &parseErr) {
+		t.Errorf("Expected failure to be a ParseError, got: %v", collectionErr.Failures)
+	}
+}
+
+var powerEventLogEntries = `{
+		"Members": [
+			{"@odata.id": "/redfish/v1/Chassis/1/LogServices/Log/Entries/1"},
+			{"@odata.id": "/redfish/v1/Chassis/1/LogServices/Log/Entries/2"},
+			{"@odata.id": "/redfish/v1/Chassis/1/LogServices/Log/Entries/3"}
+		],
+		"Members@odata.count": 3
+	}`
+
+// TestPowerRecentPowerEvents tests collecting power events from a linked log.
+func TestPowerRecentPowerEvents(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Links": {
+			"LogService": {"@odata.id": "/redfish/v1/Chassis/1/LogServices/Log"}
+		}
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(`{"Id": "Log", "Entries": {"@odata.id": "/redfish/v1/Chassis/1/LogServices/Log/Entries"}}`),
+				getCall(powerEventLogEntries),
+				getCall(`{"Id": "1", "Created": "2021-01-01T10:00:00Z", "SensorType": "Power Supply / Converter", "Message": "PSU 1 failed"}`),
+				getCall(`{"Id": "2", "Created": "2021-01-01T11:00:00Z", "SensorType": "Temperature", "Message": "Inlet warm"}`),
+				getCall(`{"Id": "3", "Created": "2021-01-01T12:00:00Z", "MessageId": "Base.1.0.ServerPoweredOn", "Message": "Server powered on"}`),
+			},
+		},
+	}
+
+	events, err := result.RecentPowerEvents(testClient)
+	if err != nil {
+		t.Fatalf("Error getting power events: %s", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 power events, got %v", events)
+	}
+
+	if events[0].Message != "Server powered on" || events[1].Message != "PSU 1 failed" {
+		t.Errorf("Expected newest events first, got %v", events)
+	}
+
+	var unlinked Power
+	events, err = unlinked.RecentPowerEvents(testClient)
+	if err != nil || events == nil || len(events) != 0 {
+		t.Errorf("Expected an empty slice without links, got %v: %v", events, err)
+	}
+}
+
+// TestPowerTopConsumer tests finding the domain drawing the most power.
+func TestPowerTopConsumer(t *testing.T) {
+	result := Power{
+		PowerControl: []PowerControl{
+			{MemberID: "0", PowerConsumedWatts: 120},
+			{MemberID: "1", PowerConsumedWatts: 480},
+			{MemberID: "2"},
+		},
+	}
+
+	top, ok := result.TopConsumer()
+	if !ok || top.MemberID != "1" {
+		t.Errorf("Expected control 1 to be the top consumer, got %v (%t)", top, ok)
+	}
+
+	result.PowerControl = result.PowerControl[2:]
+	if _, ok := result.TopConsumer(); ok {
+		t.Error("Expected no top consumer when no consumption is reported")
+	}
+}
+
+// uriClient is a mock client that returns a fixed body per URI and is safe
+// for concurrent use.
+type uriClient struct {
+	common.TestClient
+	mu     sync.Mutex
+	bodies map[string]string
+	gets   []string
+}
+
+// Get returns the body registered for the URI, or a 404 error.
+func (c *uriClient) Get(url string) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gets = append(c.gets, url)
+	body, ok := c.bodies[url]
+	if !ok {
+		return nil, common.ConstructError(http.StatusNotFound, []byte("{}"))
+	}
+	return getCall(body), nil
+}
+
+// TestPowerResolveAllVoltages tests populating voltages from Sensor resources.
+func TestPowerResolveAllVoltages(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Voltages": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Sensors/VRM1"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/Voltages/1", "MemberId": "1", "ReadingVolts": 3.3},
+			{"MemberId": "2", "DataSourceUri": "/redfish/v1/Chassis/1/Sensors/VRM2"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Sensors/Missing"},
+			{"MemberId": "4", "DataSourceUri": "/redfish/v1/Chassis/1/Sensors/CPU1Temp"},
+			{"MemberId": "5", "DataSourceUri": "/redfish/v1/Chassis/1/Sensors/PSU1Current"}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Sensors/VRM1": `{
+			"@odata.id": "/redfish/v1/Chassis/1/Sensors/VRM1",
+			"Id": "VRM1",
+			"Name": "VRM1 Voltage",
+			"PhysicalContext": "VoltageRegulator",
+			"Reading": 12.1,
+			"ReadingType": "Voltage",
+			"ReadingUnits": "V",
+			"ReadingRangeMax": 15,
+			"ReadingRangeMin": 0,
+			"Thresholds": {
+				"LowerCritical": {"Reading": 10.8},
+				"UpperCritical": {"Reading": 13.2},
+				"UpperCaution": {"Reading": 12.8}
+			}
+		}`,
+		"/redfish/v1/Chassis/1/Sensors/VRM2": `{
+			"@odata.id": "/redfish/v1/Chassis/1/Sensors/VRM2",
+			"Id": "VRM2",
+			"Name": "VRM2 Voltage",
+			"Reading": 5.02
+		}`,
+		"/redfish/v1/Chassis/1/Sensors/CPU1Temp": `{
+			"@odata.id": "/redfish/v1/Chassis/1/Sensors/CPU1Temp",
+			"Id": "CPU1Temp",
+			"Reading": 62,
+			"ReadingType": "Temperature",
+			"ReadingUnits": "Cel"
+		}`,
+		"/redfish/v1/Chassis/1/Sensors/PSU1Current": `{
+			"@odata.id": "/redfish/v1/Chassis/1/Sensors/PSU1Current",
+			"Id": "PSU1Current",
+			"Reading": 4.2,
+			"ReadingUnits": "A"
+		}`,
+	}}
+
+	err = result.ResolveAllVoltages(testClient)
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 3 {
+		t.Fatalf("Expected three failures, got: %v", err)
+	}
+	for _, uri := range []string{
+		"/redfish/v1/Chassis/1/Sensors/Missing",
+		"/redfish/v1/Chassis/1/Sensors/CPU1Temp",
+		"/redfish/v1/Chassis/1/Sensors/PSU1Current",
+	} {
+		if _, ok := collectionErr.Failures[uri]; !ok {
+			t.Errorf("Sensor %s should be reported: %v", uri, err)
+		}
+	}
+	if result.Voltages[4].ReadingVolts != 0 || result.Voltages[5].ReadingVolts != 0 {
+		t.Errorf("Sensors that do not measure voltage should not be used: %+v, %+v", result.Voltages[4], result.Voltages[5])
+	}
+
+	if len(testClient.gets) != 5 {
+		t.Errorf("Expected 5 sensor fetches, got %v", testClient.gets)
+	}
+
+	vrm1 := result.Voltages[0]
+	if vrm1.Name != "VRM1 Voltage" || vrm1.ReadingVolts != 12.1 {
+		t.Errorf("Invalid VRM1 voltage: %+v", vrm1)
+	}
+	if vrm1.LowerThresholdCritical != 10.8 || vrm1.UpperThresholdCritical != 13.2 ||
+		vrm1.UpperThresholdNonCritical != 12.8 || vrm1.MaxReadingRange != 15 {
+		t.Errorf("Invalid VRM1 thresholds: %+v", vrm1)
+	}
+	if vrm1.PhysicalContext != common.VoltageRegulatorPhysicalContext {
+		t.Errorf("Invalid VRM1 physical context: %s", vrm1.PhysicalContext)
+	}
+
+	if result.Voltages[1].ReadingVolts != 3.3 {
+		t.Errorf("Inline voltage should be unchanged: %+v", result.Voltages[1])
+	}
+
+	vrm2 := result.Voltages[2]
+	if vrm2.ReadingVolts != 5.02 || vrm2.MemberID != "2" {
+		t.Errorf("Invalid VRM2 voltage: %+v", vrm2)
+	}
+}
+
+// TestPowerFailedPresentSupplies tests finding installed but failed supplies.
+func TestPowerFailedPresentSupplies(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{"MemberId": "0", "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "1", "Status": {"State": "Enabled", "Health": "Critical"}},
+			{"MemberId": "2", "Status": {"State": "Absent", "Health": "Critical"}},
+			{"MemberId": "3", "Status": {"State": "Enabled", "Health": "Warning"}}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	failed := result.FailedPresentSupplies()
+	if len(failed) != 1 || failed[0].MemberID != "1" {
+		t.Errorf("Invalid failed supplies: %+v", failed)
+	}
+	if result.PowerSupplies[2].IsFailedPresent() {
+		t.Error("An absent supply should not be reported as failed")
+	}
+}
+
+// TestPowerSupplyNominalVoltage tests deriving the nominal line voltage.
+func TestPowerSupplyNominalVoltage(t *testing.T) {
+	tests := []struct {
+		voltageType LineInputVoltageType
+		nominal     float64
+		ok          bool
+	}{
+		{AC240VLineInputVoltageType, 240, true},
+		{ACMidLineLineInputVoltageType, 230, true},
+		{DCNeg48VLineInputVoltageType, -48, true},
+		{ACWideRangeLineInputVoltageType, 0, false},
+		{UnknownLineInputVoltageType, 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		supply := PowerSupply{LineInputVoltageType: test.voltageType, LineInputVoltage: 207.5}
+		nominal, ok := supply.NominalVoltage()
+		if nominal != test.nominal || ok != test.ok {
+			t.Errorf("%s: expected %g, %t, got %g, %t", test.voltageType, test.nominal, test.ok, nominal, ok)
+		}
+		if measured, ok := supply.MeasuredVoltage(); measured != 207.5 || !ok {
+			t.Errorf("%s: invalid measured voltage %g", test.voltageType, measured)
+		}
+	}
+}
+
+// TestPowerRedundancyLossMatrix tests the survivability of losing each supply.
+func TestPowerRedundancyLossMatrix(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 900}],
+		"PowerSupplies": [
+			{"MemberId": "0", "PowerCapacityWatts": 800, "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "1", "PowerCapacityWatts": 800, "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "2", "PowerCapacityWatts": 200, "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "3", "PowerCapacityWatts": 800, "Status": {"State": "Absent"}}
+		],
+		"Redundancy": [{"MemberId": "0", "Mode": "N+m"}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	matrix := result.RedundancyLossMatrix()
+	expected := map[string]bool{"0": true, "1": true, "2": true}
+	if len(matrix) != len(expected) {
+		t.Errorf("Invalid matrix: %v", matrix)
+	}
+	for id, survives := range expected {
+		if matrix[id] != survives {
+			t.Errorf("Supply %s: expected %t, got %t", id, survives, matrix[id])
+		}
+	}
+
+	// A failed supply no longer contributes capacity.
+	result.PowerSupplies[1].Status.Health = common.CriticalHealth
+	matrix = result.RedundancyLossMatrix()
+	if matrix["0"] || !matrix["1"] || matrix["2"] {
+		t.Errorf("Invalid matrix with a failed supply: %v", matrix)
+	}
+
+	result.PowerSupplies[1].Status.Health = common.OKHealth
+	result.Redundancy[0].Mode = NotRedundantRedundancyMode
+	for id, survives := range result.RedundancyLossMatrix() {
+		if survives {
+			t.Errorf("Supply %s: loss should not be survivable without redundancy", id)
+		}
+	}
+}
+
+// zeroCapacityPowerBody has supplies and a power control that report load
+// but no capacity.
+var zeroCapacityPowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [{
+			"MemberId": "0",
+			"PowerCapacityWatts": 0,
+			"PowerConsumedWatts": 350
+		}],
+		"PowerSupplies": [
+			{"MemberId": "0", "PowerCapacityWatts": 0, "PowerOutputWatts": 200, "LineInputVoltage": 0, "PowerInputWatts": 210},
+			{"MemberId": "1", "PowerCapacityWatts": -5, "PowerOutputWatts": 150}
+		]
+	}`
+
+// TestPowerZeroCapacity tests that utilization helpers do not produce NaN or
+// Inf values when no capacity is reported.
+func TestPowerZeroCapacity(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(zeroCapacityPowerBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	for i := range result.PowerSupplies {
+		if load, ok := result.PowerSupplies[i].LoadPercent(); ok || load != 0 {
+			t.Errorf("Supply %d: expected no load, got %g (%t)", i, load, ok)
+		}
+	}
+	if amps, ok := result.PowerSupplies[0].InputCurrentAmps(); ok || amps != 0 {
+		t.Errorf("Expected no input current without a line voltage, got %g", amps)
+	}
+
+	if distribution := result.SupplyLoadDistribution(); len(distribution) != 0 {
+		t.Errorf("Expected an empty distribution, got %v", distribution)
+	}
+	if histogram := HistogramByLoad([]*Power{&result}, 10); len(histogram) != 0 {
+		t.Errorf("Expected an empty histogram, got %v", histogram)
+	}
+
+	for id, survives := range result.RedundancyLossMatrix() {
+		if survives {
+			t.Errorf("Supply %s: loss should not be survivable without capacity", id)
+		}
+	}
+
+	for key, value := range result.FlatReadings() {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			t.Errorf("%s: invalid reading %g", key, value)
+		}
+	}
+
+	control := result.ToPowerSubsystem().ToPower().PowerControl[0]
+	if math.IsNaN(control.PowerAvailableWatts) || math.IsInf(control.PowerAvailableWatts, 0) {
+		t.Errorf("Invalid available watts: %g", control.PowerAvailableWatts)
+	}
+}
+
+// TestPowerControlSupportedMethods tests discovering the allowed methods.
+func TestPowerControlSupportedMethods(t *testing.T) {
+	control := PowerControl{MemberID: "0"}
+	control.ODataID = "/redfish/v1/Chassis/1/Power#/PowerControl/0"
+
+	optionsResponse := getCall("")
+	optionsResponse.Header.Set("Allow", "GET, HEAD, patch")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodOptions: {optionsResponse},
+		},
+	}
+
+	methods, err := control.SupportedMethods(testClient)
+	if err != nil {
+		t.Fatalf("Error getting supported methods: %s", err)
+	}
+	if strings.Join(methods, ",") != "GET,HEAD,PATCH" {
+		t.Errorf("Invalid methods: %v", methods)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != http.MethodOptions || calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	// The result is cached on the control.
+	if _, err := control.SupportedMethods(testClient); err != nil {
+		t.Errorf("Error getting cached methods: %s", err)
+	}
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Expected no further calls, got %v", testClient.CapturedCalls())
+	}
+
+	if _, err := (&PowerControl{}).SupportedMethods(&uriClient{}); err == nil {
+		t.Error("Expected an error for a control without a URI")
+	}
+}
+
+// TestGetPowerAllowHeader tests capturing the Allow header of a GET.
+func TestGetPowerAllowHeader(t *testing.T) {
+	response := getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "@odata.type": "#Power.v1_5_3.Power"}`)
+	response.Header.Set("Allow", "GET")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {response},
+		},
+	}
+
+	power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if len(power.allowedMethods) != 1 || power.allowedMethods[0] != http.MethodGet {
+		t.Errorf("Invalid allowed methods: %v", power.allowedMethods)
+	}
+}
+
+// TestPowerMetricTimeSinceReset tests reading the metric reset time.
+func TestPowerMetricTimeSinceReset(t *testing.T) {
+	var metrics []PowerMetric
+	err := json.Unmarshal([]byte(`[
+		{"AverageConsumedWatts": 300, "IntervalInMin": 60, "SensorResetTime": "2021-03-04T10:00:00Z"},
+		{"AverageConsumedWatts": 300, "IntervalInMin": 60},
+		{"AverageConsumedWatts": 300, "IntervalInMin": 60, "SensorResetTime": "not a time"}
+	]`), &metrics)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	now := time.Date(2021, 3, 4, 10, 5, 0, 0, time.UTC)
+	since, ok := metrics[0].TimeSinceReset(now)
+	if !ok || since != 5*time.Minute {
+		t.Errorf("Expected 5m since reset, got %s (%t)", since, ok)
+	}
+	if metrics[0].AverageConsumedWatts != 300 {
+		t.Errorf("Invalid AverageConsumedWatts: %g", metrics[0].AverageConsumedWatts)
+	}
+	for i := 1; i < len(metrics); i++ {
+		if _, ok := metrics[i].TimeSinceReset(now); ok {
+			t.Errorf("Metric %d: expected no reset time", i)
+		}
+	}
+}
+
+// corruptSupplyPowerBody has one malformed supply among valid ones.
+var corruptSupplyPowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerSupplies": [
+			{"MemberId": "0", "Name": "PSU1", "PowerCapacityWatts": 800},
+			{"MemberId": "1", "Name": "PSU2", "PowerCapacityWatts": "eight hundred"},
+			{"MemberId": "2", "Name": "PSU3", "PowerCapacityWatts": 800}
+		]
+	}`
+
+// TestPowerCorruptSupply tests that a malformed supply is skipped with a
+// warning rather than failing the decode.
+func TestPowerCorruptSupply(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(corruptSupplyPowerBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.PowerSupplies) != 2 {
+		t.Fatalf("Expected 2 supplies, got %d", len(result.PowerSupplies))
+	}
+	if result.PowerSupplies[0].Name != "PSU1" || result.PowerSupplies[1].Name != "PSU3" {
+		t.Errorf("Invalid supplies: %s, %s", result.PowerSupplies[0].Name, result.PowerSupplies[1].Name)
+	}
+
+	if len(result.DecodeWarnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", result.DecodeWarnings)
+	}
+	warning := result.DecodeWarnings[0]
+	if warning.Property != "PowerSupplies" || warning.Index != 1 || warning.Err == nil {
+		t.Errorf("Invalid warning: %s", warning)
+	}
+}
+
+// mapCatalog is a ModelCatalog backed by a map.
+type mapCatalog map[string]float64
+
+// RatedWatts returns the rating of model from the map.
+func (cat mapCatalog) RatedWatts(model string) (float64, bool) {
+	watts, ok := cat[model]
+	return watts, ok
+}
+
+// TestPowerValidateAgainstCatalog tests reporting capacity mismatches.
+func TestPowerValidateAgainstCatalog(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{"MemberId": "0", "Model": "PSU-800", "PowerCapacityWatts": 800},
+			{"MemberId": "1", "Model": "PSU-800", "PowerCapacityWatts": 750},
+			{"MemberId": "2", "Model": "PSU-1200", "PowerCapacityWatts": 750},
+			{"MemberId": "3", "Model": "PSU-800", "PowerCapacityWatts": 0, "Status": {"State": "Absent"}}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	mismatches := result.ValidateAgainstCatalog(mapCatalog{"PSU-800": 800})
+	if len(mismatches) != 1 {
+		t.Fatalf("Expected 1 mismatch, got %v", mismatches)
+	}
+	if !strings.Contains(mismatches[0], "power supply 1") || !strings.Contains(mismatches[0], "750") {
+		t.Errorf("Invalid mismatch: %s", mismatches[0])
+	}
+
+	if mismatches := result.ValidateAgainstCatalog(nil); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches without a catalog, got %v", mismatches)
+	}
+}
+
+// TestPowerDuplicateSensorNumbers tests finding shared voltage sensor numbers.
+func TestPowerDuplicateSensorNumbers(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Voltages": [
+			{"MemberId": "0", "SensorNumber": 11},
+			{"MemberId": "1", "SensorNumber": 12},
+			{"MemberId": "2", "SensorNumber": 11},
+			{"MemberId": "3", "SensorNumber": 4},
+			{"MemberId": "4", "SensorNumber": 4},
+			{"MemberId": "5", "SensorNumber": 4},
+			{"MemberId": "6"},
+			{"MemberId": "7"}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	duplicates := result.DuplicateSensorNumbers()
+	if fmt.Sprint(duplicates) != "[4 11]" {
+		t.Errorf("Invalid duplicates: %v", duplicates)
+	}
+}
+
+// TestPowerSupplyActiveConditions tests reading the status conditions.
+func TestPowerSupplyActiveConditions(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [{
+			"MemberId": "0",
+			"Status": {
+				"State": "Enabled",
+				"Health": "Critical",
+				"Conditions": [{
+					"MessageId": "Power.1.0.PowerSupplyFailed",
+					"Message": "Power supply 0 has failed.",
+					"MessageArgs": ["0"],
+					"Severity": "Critical",
+					"OriginOfCondition": {"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0"},
+					"Timestamp": "2022-06-01T12:00:00Z"
+				}]
+			}
+		}, {
+			"MemberId": "1",
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	conditions := result.PowerSupplies[0].ActiveConditions()
+	if len(conditions) != 1 {
+		t.Fatalf("Expected 1 condition, got %d", len(conditions))
+	}
+	condition := conditions[0]
+	if condition.MessageID != "Power.1.0.PowerSupplyFailed" || condition.Severity != common.CriticalHealth {
+		t.Errorf("Invalid condition: %+v", condition)
+	}
+	if condition.OriginOfCondition != "/redfish/v1/Chassis/1/Power#/PowerSupplies/0" {
+		t.Errorf("Invalid origin: %s", condition.OriginOfCondition)
+	}
+
+	if conditions := result.PowerSupplies[1].ActiveConditions(); len(conditions) != 0 {
+		t.Errorf("Expected no conditions, got %v", conditions)
+	}
+}
+
+// TestPowerRedundancyShortfall tests counting missing redundancy members.
+func TestPowerRedundancyShortfall(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0", "MemberId": "0", "Status": {"State": "Enabled", "Health": "OK"}},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1", "MemberId": "1", "Status": {"State": "Enabled", "Health": "Critical"}},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/2", "MemberId": "2", "Status": {"State": "Absent"}},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/3", "MemberId": "3", "Status": {"State": "Enabled", "Health": "OK"}}
+		],
+		"Redundancy": [{
+			"MemberId": "0",
+			"Name": "PSU Group A",
+			"MinNumNeeded": 3,
+			"MaxNumSupported": 3,
+			"RedundancySet": [
+				{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0"},
+				{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1"},
+				{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/2"}
+			]
+		}, {
+			"MemberId": "1",
+			"MinNumNeeded": 2
+		}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	shortfall := result.RedundancyShortfall()
+	if len(shortfall) != 2 {
+		t.Errorf("Expected 2 groups, got %v", shortfall)
+	}
+	if shortfall["PSU Group A"] != 2 {
+		t.Errorf("Expected group A to be 2 members short, got %d", shortfall["PSU Group A"])
+	}
+	if missing, ok := shortfall["1"]; !ok || missing != 0 {
+		t.Errorf("Expected unnamed group to be satisfied, got %d (%t)", missing, ok)
+	}
+}
+
+// TestPowerSupplyApparentPowerVA tests computing apparent power.
+func TestPowerSupplyApparentPowerVA(t *testing.T) {
+	var supply PowerSupply
+	err := json.Unmarshal([]byte(`{"MemberId": "0", "PowerInputWatts": 475, "PowerFactor": 0.95}`), &supply)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	va, ok := supply.ApparentPowerVA()
+	if !ok || math.Abs(va-500) > 1e-9 {
+		t.Errorf("Expected 500 VA, got %g (%t)", va, ok)
+	}
+
+	for _, factor := range []float64{0, -0.5, 1.2} {
+		supply.PowerFactor = factor
+		if _, ok := supply.ApparentPowerVA(); ok {
+			t.Errorf("Power factor %g should not give an apparent power", factor)
+		}
+	}
+}
+
+// TestPowerSummary tests the one line summary.
+func TestPowerSummary(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 1240, "PowerCapacityWatts": 2000}],
+		"PowerSupplies": [
+			{"MemberId": "0", "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "1", "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "2", "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "3", "Status": {"State": "Absent"}}
+		],
+		"Redundancy": [{"MemberId": "0", "Name": "PSU", "MinNumNeeded": 2}],
+		"Voltages": [
+			{"MemberId": "0", "ReadingVolts": 12, "Status": {"Health": "OK"}},
+			{"MemberId": "1", "ReadingVolts": 5}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	expected := "Power: 3/4 PSUs present, 1240W/2000W (62%), redundancy OK, 2 voltages nominal"
+	if summary := result.Summary(); summary != expected {
+		t.Errorf("Expected %q, got %q", expected, summary)
+	}
+
+	result.Redundancy[0].MinNumNeeded = 4
+	result.Voltages[1].Status.Health = common.WarningHealth
+	expected = "Power: 3/4 PSUs present, 1240W/2000W (62%), redundancy short 1, 1/2 voltages nominal"
+	if summary := result.Summary(); summary != expected {
+		t.Errorf("Expected %q, got %q", expected, summary)
+	}
+
+	if summary := (&Power{}).Summary(); summary != "Power: 0/0 PSUs present, consumption unknown, no redundancy, 0 voltages nominal" {
+		t.Errorf("Unexpected empty summary: %q", summary)
+	}
+}
+
+// TestPowerString tests describing Power and its members for logging.
+func TestPowerString(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [
+			{"MemberId": "0", "PowerConsumedWatts": 344, "PowerCapacityWatts": 800,
+				"PowerLimit": {"LimitInWatts": 500}, "Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "1"}
+		],
+		"PowerSupplies": [
+			{"MemberId": "1", "PowerSupplyType": "AC", "PowerOutputWatts": 750, "EfficiencyPercent": 92,
+				"Status": {"State": "Enabled", "Health": "OK"}},
+			{"MemberId": "2", "PowerSupplyType": "Unknown", "Status": {"State": "Absent"}}
+		],
+		"Voltages": [{"MemberId": "0", "Name": "VRM1", "ReadingVolts": 1.8, "Status": {"State": "Enabled", "Health": "Warning"}}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	tests := []struct {
+		value    fmt.Stringer
+		expected string
+	}{
+		{&result, result.Summary()},
+		{&result.PowerControl[0], "PowerControl[0] 344W/800W, limit 500W, OK"},
+		{&result.PowerControl[1], "PowerControl[1] consumption unknown, status unknown"},
+		{&result.PowerSupplies[0], "PSU[1] AC 750W out, 92% eff, OK"},
+		{&result.PowerSupplies[1], "PSU[2], absent"},
+		{&result.Voltages[0], "Voltage[0] VRM1 1.8V, Warning"},
+	}
+
+	for _, test := range tests {
+		if s := test.value.String(); s != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, s)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = result.PowerSupplies[0].String() }); allocs > 2 {
+		t.Errorf("Expected at most 2 allocations, got %g", allocs)
+	}
+}
+
+// TestPowerBudgetTree tests walking the chassis containment for budgets.
+func TestPowerBudgetTree(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/Enclosure/Power",
+		"PowerControl": [{"MemberId": "0", "PowerAllocatedWatts": 3000, "PowerAvailableWatts": 1000}]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/Enclosure": `{
+			"@odata.id": "/redfish/v1/Chassis/Enclosure",
+			"Name": "Enclosure",
+			"Power": {"@odata.id": "/redfish/v1/Chassis/Enclosure/Power"},
+			"Links": {"Contains": [
+				{"@odata.id": "/redfish/v1/Chassis/Sled1"},
+				{"@odata.id": "/redfish/v1/Chassis/Sled2"}
+			]}
+		}`,
+		"/redfish/v1/Chassis/Sled1": `{
+			"@odata.id": "/redfish/v1/Chassis/Sled1",
+			"Name": "Sled 1",
+			"Power": {"@odata.id": "/redfish/v1/Chassis/Sled1/Power"}
+		}`,
+		"/redfish/v1/Chassis/Sled1/Power": `{
+			"@odata.id": "/redfish/v1/Chassis/Sled1/Power",
+			"PowerControl": [{"MemberId": "0", "PowerAllocatedWatts": 1200, "PowerAvailableWatts": 300}]
+		}`,
+		"/redfish/v1/Chassis/Sled2": `{
+			"@odata.id": "/redfish/v1/Chassis/Sled2",
+			"Name": "Sled 2",
+			"Links": {"Contains": [{"@odata.id": "/redfish/v1/Chassis/Enclosure"}]}
+		}`,
+	}}
+
+	tree, err := result.BudgetTree(testClient)
+	if err != nil {
+		t.Fatalf("Error building budget tree: %s", err)
+	}
+
+	if tree.Name != "Enclosure" || tree.AllocatedWatts != 3000 || tree.AvailableWatts != 1000 {
+		t.Errorf("Invalid root node: %+v", tree)
+	}
+	if len(tree.Children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(tree.Children))
+	}
+	if sled := tree.Children[0]; sled.Name != "Sled 1" || sled.AllocatedWatts != 1200 || sled.AvailableWatts != 300 {
+		t.Errorf("Invalid sled 1 node: %+v", sled)
+	}
+	if sled := tree.Children[1]; sled.AllocatedWatts != 0 || len(sled.Children) != 0 {
+		t.Errorf("Invalid sled 2 node: %+v", sled)
+	}
+
+	// The enclosure Power resource is not fetched again.
+	for _, uri := range testClient.gets {
+		if uri == "/redfish/v1/Chassis/Enclosure/Power" {
+			t.Error("The known Power resource should not be fetched")
+		}
+	}
+}
+
+// objectPowerControlBody has PowerControl as an object rather than an array.
+var objectPowerControlBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"@odata.type": "#Power.v1_5_3.Power",
+		"Id": "Power",
+		"PowerControl": {
+			"MemberId": "0",
+			"PowerConsumedWatts": 350
+		}
+	}`
+
+// TestGetPowerStrictSchema tests rejecting an object-shaped PowerControl
+// when the client is configured for strict schema handling.
+func TestGetPowerStrictSchema(t *testing.T) {
+	testClient := &common.TestClient{
+		StrictSchema: true,
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(objectPowerControlBody)},
+		},
+	}
+
+	_, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	var parseErr *common.ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, common.ErrSchemaViolation) {
+		t.Errorf("Expected a schema violation, got: %v", err)
+	}
+
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(objectPowerControlBody)},
+		},
+	}
+	power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Object-shaped PowerControl should be tolerated by default: %v", err)
+	}
+	if len(power.PowerControl) != 1 || power.PowerControl[0].PowerConsumedWatts != 350 {
+		t.Errorf("Expected the PowerControl object to be decoded: %+v", power.PowerControl)
+	}
+}
+
+// TestGetPowerPowerControlShapes tests that PowerControl is decoded from the
+// array form required by the schema and from the forms some services use.
+func TestGetPowerPowerControlShapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		control  string
+		consumed []float64
+	}{
+		{"array", `[{"MemberId": "0", "PowerConsumedWatts": 344}, {"MemberId": "1", "PowerConsumedWatts": 120}]`, []float64{344, 120}},
+		{"object", `{"MemberId": "0", "PowerConsumedWatts": 344}`, []float64{344}},
+		{"strings", `[{"MemberId": "0", "PowerConsumedWatts": "344.5", "PowerCapacityWatts": " 800 ", "PowerAvailableWatts": ""}]`, []float64{344.5}},
+		{"null", `null`, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testClient := &common.TestClient{
+				CustomReturnForActions: map[string][]interface{}{
+					http.MethodGet: {getCall(`{
+						"@odata.id": "/redfish/v1/Chassis/1/Power",
+						"@odata.type": "#Power.v1_5_3.Power",
+						"Id": "Power",
+						"PowerControl": ` + test.control + `
+					}`)},
+				},
+			}
+
+			power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+			if err != nil {
+				t.Fatalf("Error getting power: %s", err)
+			}
+
+			var consumed []float64
+			for i := range power.PowerControl {
+				consumed = append(consumed, power.PowerControl[i].PowerConsumedWatts)
+				if power.PowerControl[i].Client == nil {
+					t.Errorf("Expected PowerControl %d to have a client", i)
+				}
+			}
+			if !reflect.DeepEqual(consumed, test.consumed) {
+				t.Errorf("Expected consumption %v, got %v", test.consumed, consumed)
+			}
+		})
+	}
+
+	var control PowerControl
+	if err := json.Unmarshal([]byte(`{"PowerCapacityWatts": "800", "PowerConsumedWatts": null}`), &control); err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	if capacity := control.CapacityWatts(); !capacity.Present || capacity.Value != 800 {
+		t.Errorf("Expected a capacity of 800 W from a string, got %+v", capacity)
+	}
+	if control.ConsumedWatts().Present {
+		t.Error("Expected a null consumption to be reported as missing")
+	}
+
+	if err := json.Unmarshal([]byte(`{"PowerConsumedWatts": "N/A"}`), &control); err == nil {
+		t.Error("Expected an error for a non-numeric string")
+	}
+}
+
+// TestPowerChassisInfo tests resolving the identifying strings of the chassis.
+func TestPowerChassisInfo(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1": `{
+			"@odata.id": "/redfish/v1/Chassis/1",
+			"AssetTag": "RACK12-U4",
+			"SKU": "8675309",
+			"SerialNumber": "437XR1138R2"
+		}`,
+	}}
+
+	result := &Power{}
+	result.ODataID = "/redfish/v1/Chassis/1/Power"
+
+	sku, assetTag, serial, err := result.ChassisInfo(testClient)
+	if err != nil {
+		t.Fatalf("Error getting chassis info: %s", err)
+	}
+	if sku != "8675309" || assetTag != "RACK12-U4" || serial != "437XR1138R2" {
+		t.Errorf("Invalid chassis info: %s, %s, %s", sku, assetTag, serial)
+	}
+
+	result.ODataID = "/redfish/v1/Chassis/2/Power"
+	if _, _, _, err := result.ChassisInfo(testClient); err == nil {
+		t.Error("Expected an error for a missing chassis")
+	}
+
+	// The client of the resource is used by default
+	result.ODataID = "/redfish/v1/Chassis/1/Power"
+	if _, _, _, err := result.ChassisInfo(nil); err == nil {
+		t.Error("Expected an error without a client")
+	}
+	result.SetClient(testClient)
+	if sku, _, _, err := result.ChassisInfo(nil); err != nil || sku != "8675309" {
+		t.Errorf("Expected the client of the resource to be used, got %q: %v", sku, err)
+	}
+
+	// Power resources not belonging to a chassis are rejected rather than
+	// resolving to an unrelated resource
+	testClient.bodies["/redfish/v1/Systems/1"] = `{"SKU": "unrelated"}`
+	result.ODataID = "/redfish/v1/Systems/1/Power"
+	if _, _, _, err := result.ChassisInfo(nil); err == nil {
+		t.Error("Expected an error for a Power resource outside a chassis")
+	}
+	if _, err := result.BudgetTree(nil); err == nil {
+		t.Error("Expected an error building a budget tree outside a chassis")
+	}
+}
+
+// TestPowerHasChanged tests conditional re-reads with the entity tag.
+func TestPowerHasChanged(t *testing.T) {
+	first := getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "@odata.type": "#Power.v1_5_3.Power", "Name": "Before"}`)
+	first.Header.Set("ETag", `W/"1"`)
+	notModified := getCall("")
+	notModified.StatusCode = http.StatusNotModified
+	changed := getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "@odata.type": "#Power.v1_5_3.Power", "Name": "After"}`)
+	changed.Header.Set("ETag", `W/"2"`)
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {first, notModified, changed},
+		},
+	}
+
+	power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	isChanged, err := power.HasChanged(testClient)
+	if err != nil || isChanged {
+		t.Errorf("Expected no change, got %t, %v", isChanged, err)
+	}
+	if power.Name != "Before" {
+		t.Errorf("Unchanged resource should not be updated: %s", power.Name)
+	}
+
+	isChanged, err = power.HasChanged(testClient)
+	if err != nil || !isChanged {
+		t.Errorf("Expected a change, got %t, %v", isChanged, err)
+	}
+	if power.Name != "After" || power.etag != `W/"2"` {
+		t.Errorf("Changed resource should be updated: %s, %s", power.Name, power.etag)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[1].CustomHeaders["If-None-Match"] != `W/"1"` || calls[2].CustomHeaders["If-None-Match"] != `W/"1"` {
+		t.Errorf("Unexpected conditional headers: %v, %v", calls[1].CustomHeaders, calls[2].CustomHeaders)
+	}
+}
+
+// TestGetPowerUseNumber tests keeping the original text of numbers.
+func TestGetPowerUseNumber(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"@odata.type": "#Power.v1_5_3.Power",
+		"PowerControl": [{
+			"MemberId": "0",
+			"PowerMetrics": {"IntervalInMin": 1.0, "AverageConsumedWatts": 300}
+		}],
+		"PowerSupplies": [{"MemberId": "0", "PowerCapacityWatts": 800}]
+	}`
+	testClient := &common.TestClient{
+		UseNumber: true,
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(body), getCall(body)},
+		},
+	}
+
+	power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	if interval, ok := power.Number("/PowerControl/0/PowerMetrics/IntervalInMin"); !ok || interval.String() != "1.0" {
+		t.Errorf("Expected IntervalInMin sent as 1.0, got %q (%t)", interval, ok)
+	}
+	if capacity, ok := power.Number("/PowerSupplies/0/PowerCapacityWatts"); !ok || capacity.String() != "800" {
+		t.Errorf("Expected PowerCapacityWatts sent as 800, got %q (%t)", capacity, ok)
+	}
+	if power.PowerSupplies[0].PowerCapacityWatts != 800 {
+		t.Errorf("Decoded fields should be unaffected: %g", power.PowerSupplies[0].PowerCapacityWatts)
+	}
+
+	testClient.UseNumber = false
+	power, err = GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if _, ok := power.Number("/PowerSupplies/0/PowerCapacityWatts"); ok {
+		t.Error("Numbers should only be kept when opted in")
+	}
+}
+
+// TestMergePower tests combining partial Power resources.
+func TestMergePower(t *testing.T) {
+	var supplies, voltages Power
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Name": "Power",
+		"PowerSupplies": [{"MemberId": "0"}, {"MemberId": "1"}],
+		"PowerSupplies@odata.count": 2
+	}`), &supplies)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	err = json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Name": "Chassis Power",
+		"Description": "Power sensors",
+		"PowerSupplies": [{"MemberId": "9"}],
+		"Voltages": [{"MemberId": "0", "ReadingVolts": 12}],
+		"Voltages@odata.count": 1
+	}`), &voltages)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	merged := MergePower(&supplies, &voltages)
+
+	if merged.Name != "Power" {
+		t.Errorf("The first value should win, got name %s", merged.Name)
+	}
+	if merged.Description != "Power sensors" {
+		t.Errorf("Missing values should be filled in, got description %q", merged.Description)
+	}
+	if len(merged.PowerSupplies) != 2 || merged.PowerSuppliesCount != 2 {
+		t.Errorf("Expected the supplies of the first resource, got %d", len(merged.PowerSupplies))
+	}
+	if len(merged.Voltages) != 1 || merged.VoltagesCount != 1 || merged.Voltages[0].ReadingVolts != 12 {
+		t.Errorf("Expected the voltages of the second resource, got %+v", merged.Voltages)
+	}
+	if supplies.Description != "" || len(supplies.Voltages) != 0 {
+		t.Error("Inputs should not be changed")
+	}
+
+	if merged := MergePower(nil, &voltages); merged.Name != "Chassis Power" {
+		t.Errorf("Expected the second resource, got %s", merged.Name)
+	}
+}
+
+// TestPowerSupplySetIndicator tests that the indicator LED is only changed
+// when the supply supports it.
+func TestPowerSupplySetIndicator(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0", "MemberId": "0", "IndicatorLED": "Off",
+				"@Redfish.WriteableProperties": ["IndicatorLED"]},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1", "MemberId": "1"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/2", "MemberId": "2", "IndicatorLED": "Off",
+				"@Redfish.WriteableProperties": []},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/3", "MemberId": "3", "IndicatorLED": "Off"}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	for i := range result.PowerSupplies {
+		result.PowerSupplies[i].SetClient(testClient)
+	}
+
+	for i, supported := range []bool{true, false, false, true} {
+		if result.PowerSupplies[i].SupportsIndicatorLED() != supported {
+			t.Errorf("Supply %d: expected support %t", i, supported)
+		}
+	}
+
+	if err := result.PowerSupplies[1].SetIndicator(common.LitIndicatorLED); !errors.Is(err, common.ErrUpdateNotSupported) {
+		t.Errorf("Expected ErrUpdateNotSupported, got: %v", err)
+	}
+	if err := result.PowerSupplies[2].SetIndicator(common.LitIndicatorLED); !errors.Is(err, common.ErrUpdateNotSupported) {
+		t.Errorf("Expected ErrUpdateNotSupported, got: %v", err)
+	}
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unsupported updates should not be sent: %v", testClient.CapturedCalls())
+	}
+
+	if err := result.PowerSupplies[0].SetIndicator(common.LitIndicatorLED); err != nil {
+		t.Errorf("Error setting indicator: %s", err)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != http.MethodPatch || !strings.Contains(calls[0].Payload, "IndicatorLED:Lit") {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestGetPowerWithOptions tests adding query parameters to the request.
+func TestGetPowerWithOptions(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "@odata.type": "#Power.v1_5_3.Power"}`)},
+		},
+	}
+
+	query := common.NewQuery().WithExpandLevels(1).WithParam("oem.detail", "full")
+	power, err := GetPowerWithOptions(testClient, "/redfish/v1/Chassis/1/Power", query)
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if power.ODataID != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Invalid ODataID: %s", power.ODataID)
+	}
+
+	calls := testClient.CapturedCalls()
+	expected := "/redfish/v1/Chassis/1/Power?$expand=.%28%24levels%3D1%29&oem.detail=full"
+	if len(calls) != 1 || calls[0].URL != expected {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestPowerAggregateEfficiency tests the combined efficiency of the supplies.
+func TestPowerAggregateEfficiency(t *testing.T) {
+	decode := func(body string) *Power {
+		var power Power
+		if err := json.Unmarshal([]byte(body), &power); err != nil {
+			t.Fatalf("Error decoding JSON: %s", err)
+		}
+		return &power
+	}
+
+	measured := decode(`{"PowerSupplies": [
+		{"MemberId": "0", "PowerInputWatts": 500, "PowerOutputWatts": 450},
+		{"MemberId": "1", "PowerInputWatts": 500, "PowerOutputWatts": 470},
+		{"MemberId": "2", "PowerInputWatts": 500, "PowerOutputWatts": 480, "Status": {"State": "Absent"}},
+		{"MemberId": "3", "PowerOutputWatts": 100}
+	]}`)
+	efficiency, estimated, ok := measured.AggregateEfficiencyEstimate()
+	if !ok || estimated || math.Abs(efficiency-92) > 1e-9 {
+		t.Errorf("Expected 92%% measured, got %g (estimated %t, ok %t)", efficiency, estimated, ok)
+	}
+
+	mixed := decode(`{"PowerSupplies": [
+		{"MemberId": "0", "PowerInputWatts": 500, "PowerOutputWatts": 450},
+		{"MemberId": "1", "PowerOutputWatts": 400, "EfficiencyPercent": 80}
+	]}`)
+	efficiency, estimated, ok = mixed.AggregateEfficiencyEstimate()
+	if !ok || !estimated || math.Abs(efficiency-85) > 1e-9 {
+		t.Errorf("Expected 85%% estimated, got %g (estimated %t, ok %t)", efficiency, estimated, ok)
+	}
+
+	skewed := decode(`{"PowerSupplies": [{"MemberId": "0", "PowerInputWatts": 400, "PowerOutputWatts": 410}]}`)
+	if efficiency, ok := skewed.AggregateEfficiency(); !ok || efficiency != 100 {
+		t.Errorf("Expected efficiency clamped to 100%%, got %g (%t)", efficiency, ok)
+	}
+
+	unknown := decode(`{"PowerSupplies": [{"MemberId": "0", "PowerOutputWatts": 410}]}`)
+	if _, ok := unknown.AggregateEfficiency(); ok {
+		t.Error("Expected no efficiency without input readings")
+	}
+}
+
+// TestVoltageReadingVariants tests decoding the reading from both the
+// ReadingVolts and the Reading and ReadingUnits forms.
+func TestVoltageReadingVariants(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected float64
+	}{
+		{`{"MemberId": "0", "ReadingVolts": 12.1}`, 12.1},
+		{`{"MemberId": "0", "Reading": 3.3, "ReadingUnits": "Volts"}`, 3.3},
+		{`{"MemberId": "0", "Reading": 3.3, "ReadingUnits": "A"}`, 0},
+		{`{"MemberId": "0", "ReadingVolts": 5, "Reading": 3.3, "ReadingUnits": "Volts"}`, 5},
+		{`{"MemberId": 1, "Reading": 1.8, "ReadingUnits": "Volts"}`, 1.8},
+	}
+
+	for _, test := range tests {
+		var voltage Voltage
+		if err := json.Unmarshal([]byte(test.body), &voltage); err != nil {
+			t.Errorf("Error decoding %s: %s", test.body, err)
+			continue
+		}
+		if voltage.ReadingVolts != test.expected {
+			t.Errorf("%s: expected ReadingVolts %g, got %g", test.body, test.expected, voltage.ReadingVolts)
+		}
+	}
+}
+
+// mockupRackmountPowerBody is modelled on the Power resource of the
+// public-rackmount1 DMTF mockup: AC supplies in an N+m redundancy group.
+var mockupRackmountPowerBody = `{
+	"@odata.type": "#Power.v1_5_2.Power",
+	"Id": "Power",
+	"Name": "Power",
+	"PowerControl": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerControl/0",
+			"MemberId": "0",
+			"Name": "Server Power Control",
+			"PowerConsumedWatts": 344,
+			"PowerRequestedWatts": 800,
+			"PowerAvailableWatts": 0,
+			"PowerCapacityWatts": 800,
+			"PowerAllocatedWatts": 800,
+			"PowerMetrics": {
+				"IntervalInMin": 30,
+				"MinConsumedWatts": 271,
+				"MaxConsumedWatts": 489,
+				"AverageConsumedWatts": 319
+			},
+			"PowerLimit": {
+				"LimitInWatts": 500,
+				"LimitException": "LogEventOnly",
+				"CorrectionInMs": 50
+			},
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}
+	],
+	"Voltages": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/Voltages/0",
+			"MemberId": "0",
+			"Name": "VRM1 Voltage",
+			"SensorNumber": 11,
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"ReadingVolts": 12,
+			"UpperThresholdNonCritical": 12.5,
+			"UpperThresholdCritical": 13,
+			"UpperThresholdFatal": 15,
+			"LowerThresholdNonCritical": 11.5,
+			"LowerThresholdCritical": 11,
+			"LowerThresholdFatal": 10,
+			"MinReadingRange": 0,
+			"MaxReadingRange": 20,
+			"PhysicalContext": "VoltageRegulator"
+		},
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/Voltages/1",
+			"MemberId": "1",
+			"Name": "VRM2 Voltage",
+			"SensorNumber": 12,
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"ReadingVolts": 5,
+			"UpperThresholdNonCritical": 5.5,
+			"UpperThresholdCritical": 7,
+			"LowerThresholdNonCritical": 4.75,
+			"LowerThresholdCritical": 4.5,
+			"MinReadingRange": 0,
+			"MaxReadingRange": 20,
+			"PhysicalContext": "VoltageRegulator"
+		}
+	],
+	"PowerSupplies": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"Name": "Power Supply Bay",
+			"Status": {"State": "Enabled", "Health": "Warning"},
+			"PowerSupplyType": "AC",
+			"LineInputVoltageType": "ACWideRange",
+			"LineInputVoltage": 120,
+			"PowerCapacityWatts": 800,
+			"PowerInputWatts": 190,
+			"PowerOutputWatts": 175,
+			"EfficiencyPercent": 92,
+			"LastPowerOutputWatts": 325,
+			"Model": "499253-B21",
+			"Manufacturer": "ManufacturerName",
+			"FirmwareVersion": "1.00",
+			"SerialNumber": "1Z0000001",
+			"PartNumber": "0000001A3A",
+			"SparePartNumber": "0000001A3A",
+			"InputRanges": [
+				{
+					"InputType": "AC",
+					"MinimumVoltage": 100,
+					"MaximumVoltage": 120,
+					"OutputWattage": 800,
+					"MinimumFrequencyHz": 50,
+					"MaximumFrequencyHz": 60
+				}
+			],
+			"IndicatorLED": "Off"
+		},
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerSupplies/1",
+			"MemberId": "1",
+			"Name": "Power Supply Bay",
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"PowerSupplyType": "AC",
+			"LineInputVoltageType": "ACWideRange",
+			"LineInputVoltage": 120,
+			"PowerCapacityWatts": 800,
+			"PowerInputWatts": 185,
+			"PowerOutputWatts": 169,
+			"EfficiencyPercent": 92,
+			"LastPowerOutputWatts": 319,
+			"Model": "499253-B21",
+			"Manufacturer": "ManufacturerName",
+			"FirmwareVersion": "1.00",
+			"SerialNumber": "1Z0000002",
+			"IndicatorLED": "Off"
+		}
+	],
+	"Redundancy": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power#/Redundancy/0",
+			"MemberId": "0",
+			"Name": "PowerSupply Redundancy Group 1",
+			"Mode": "N+m",
+			"MaxNumSupported": 2,
+			"MinNumNeeded": 1,
+			"RedundancySet": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerSupplies/0"},
+				{"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerSupplies/1"}
+			],
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}
+	],
+	"@odata.id": "/redfish/v1/Chassis/1U/Power"
+}`
+
+// mockupDCPowerBody is modelled on a DC powered chassis from the DMTF
+// mockups, with one bay empty and no redundancy.
+var mockupDCPowerBody = `{
+	"@odata.type": "#Power.v1_5_2.Power",
+	"Id": "Power",
+	"Name": "Power",
+	"PowerControl": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/DC/Power#/PowerControl/0",
+			"MemberId": "0",
+			"Name": "Chassis Power Control",
+			"PowerConsumedWatts": 410,
+			"PowerCapacityWatts": 1100,
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}
+	],
+	"Voltages": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/DC/Power#/Voltages/0",
+			"MemberId": "0",
+			"Name": "Input Voltage",
+			"SensorNumber": 21,
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"ReadingVolts": -48.2,
+			"MinReadingRange": -60,
+			"MaxReadingRange": 0,
+			"PhysicalContext": "PowerSupply"
+		}
+	],
+	"PowerSupplies": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/DC/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"Name": "DC Power Supply 1",
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"PowerSupplyType": "DC",
+			"LineInputVoltageType": "DCNeg48V",
+			"LineInputVoltage": -48.2,
+			"PowerCapacityWatts": 1100,
+			"PowerOutputWatts": 410,
+			"EfficiencyPercent": 94,
+			"Model": "DC-1100",
+			"Manufacturer": "ManufacturerName"
+		},
+		{
+			"@odata.id": "/redfish/v1/Chassis/DC/Power#/PowerSupplies/1",
+			"MemberId": "1",
+			"Name": "DC Power Supply 2",
+			"Status": {"State": "Absent"}
+		}
+	],
+	"Redundancy": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/DC/Power#/Redundancy/0",
+			"MemberId": "0",
+			"Name": "PowerSupply Redundancy Group 1",
+			"Mode": "NotRedundant",
+			"MaxNumSupported": 2,
+			"MinNumNeeded": 1,
+			"RedundancySet": [
+				{"@odata.id": "/redfish/v1/Chassis/DC/Power#/PowerSupplies/0"},
+				{"@odata.id": "/redfish/v1/Chassis/DC/Power#/PowerSupplies/1"}
+			],
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}
+	],
+	"@odata.id": "/redfish/v1/Chassis/DC/Power"
+}`
+
+// mockupCappedPowerBody is modelled on a power capped enclosure from the
+// DMTF mockups, with one failed supply in a sharing group needing two.
+var mockupCappedPowerBody = `{
+	"@odata.type": "#Power.v1_5_2.Power",
+	"Id": "Power",
+	"Name": "Power",
+	"PowerControl": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/PowerControl/0",
+			"MemberId": "0",
+			"Name": "Enclosure Power Control",
+			"PowerConsumedWatts": 1450,
+			"PowerCapacityWatts": 2400,
+			"PowerLimit": {
+				"LimitInWatts": 1500,
+				"LimitException": "HardPowerOff",
+				"CorrectionInMs": 1000
+			},
+			"Status": {"State": "Enabled", "Health": "Warning"}
+		}
+	],
+	"PowerSupplies": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"Name": "Power Supply 1",
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"PowerSupplyType": "AC",
+			"LineInputVoltageType": "AC240V",
+			"LineInputVoltage": 238,
+			"PowerCapacityWatts": 1200,
+			"PowerInputWatts": 1560,
+			"PowerOutputWatts": 1450
+		},
+		{
+			"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/PowerSupplies/1",
+			"MemberId": "1",
+			"Name": "Power Supply 2",
+			"Status": {"State": "Enabled", "Health": "Critical"},
+			"PowerSupplyType": "AC",
+			"LineInputVoltageType": "AC240V",
+			"LineInputVoltage": 0,
+			"PowerCapacityWatts": 1200,
+			"PowerOutputWatts": 0
+		}
+	],
+	"Redundancy": [
+		{
+			"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/Redundancy/0",
+			"MemberId": "0",
+			"Name": "PowerSupply Redundancy Group 1",
+			"Mode": "Sharing",
+			"MaxNumSupported": 2,
+			"MinNumNeeded": 2,
+			"RedundancySet": [
+				{"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/PowerSupplies/0"},
+				{"@odata.id": "/redfish/v1/Chassis/Enclosure/Power#/PowerSupplies/1"}
+			],
+			"Status": {"State": "Enabled", "Health": "Critical"}
+		}
+	],
+	"@odata.id": "/redfish/v1/Chassis/Enclosure/Power"
+}`
+
+// TestPowerMockups tests parsing and the power helpers against resources
+// modelled on the DMTF mockups.
+func TestPowerMockups(t *testing.T) {
+	tests := []struct {
+		name         string
+		uri          string
+		body         string
+		supplies     int
+		voltages     int
+		failed       []string
+		consumed     float64
+		headroom     float64
+		lossMatrix   map[string]bool
+		shortfall    map[string]int
+		nominalVolts float64
+	}{
+		{
+			name:       "rackmount",
+			uri:        "/redfish/v1/Chassis/1U/Power",
+			body:       mockupRackmountPowerBody,
+			supplies:   2,
+			voltages:   2,
+			consumed:   344,
+			headroom:   456,
+			lossMatrix: map[string]bool{"0": true, "1": true},
+			shortfall:  map[string]int{"PowerSupply Redundancy Group 1": 0},
+		},
+		{
+			name:         "dc",
+			uri:          "/redfish/v1/Chassis/DC/Power",
+			body:         mockupDCPowerBody,
+			supplies:     2,
+			voltages:     1,
+			consumed:     410,
+			headroom:     690,
+			lossMatrix:   map[string]bool{"0": false},
+			shortfall:    map[string]int{"PowerSupply Redundancy Group 1": 0},
+			nominalVolts: -48,
+		},
+		{
+			name:         "capped",
+			uri:          "/redfish/v1/Chassis/Enclosure/Power",
+			body:         mockupCappedPowerBody,
+			supplies:     2,
+			failed:       []string{"1"},
+			consumed:     1450,
+			headroom:     950,
+			lossMatrix:   map[string]bool{"0": false, "1": false},
+			shortfall:    map[string]int{"PowerSupply Redundancy Group 1": 1},
+			nominalVolts: 240,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testClient := &uriClient{bodies: map[string]string{test.uri: test.body}}
+			parsed, err := GetPower(testClient, test.uri)
+			if err != nil {
+				t.Fatalf("Error getting power: %s", err)
+			}
+			if parsed.ODataID != test.uri {
+				t.Errorf("Expected ODataID %s, got %s", test.uri, parsed.ODataID)
+			}
+			if len(parsed.PowerSupplies) != test.supplies {
+				t.Errorf("Expected %d supplies, got %d", test.supplies, len(parsed.PowerSupplies))
+			}
+			if len(parsed.Voltages) != test.voltages {
+				t.Errorf("Expected %d voltages, got %d", test.voltages, len(parsed.Voltages))
+			}
+			if len(parsed.Redundancy) != 1 {
+				t.Errorf("Expected 1 redundancy group, got %d", len(parsed.Redundancy))
+			}
+			if consumed := parsed.chassisControl().ConsumedWatts(); consumed.Value != test.consumed {
+				t.Errorf("Expected GetPower to report %g W consumed, got %+v", test.consumed, consumed)
+			}
+
+			var power Power
+			if err := json.Unmarshal([]byte(test.body), &power); err != nil {
+				t.Fatalf("Error decoding JSON: %s", err)
+			}
+
+			var failed []string
+			for _, supply := range power.FailedPresentSupplies() {
+				failed = append(failed, supply.MemberID)
+			}
+			if !reflect.DeepEqual(failed, test.failed) {
+				t.Errorf("Expected failed supplies %v, got %v", test.failed, failed)
+			}
+
+			consumed := TotalConsumedWatts([]*Power{&power})
+			if !consumed.Present || consumed.Value != test.consumed {
+				t.Errorf("Expected %g W consumed, got %+v", test.consumed, consumed)
+			}
+			headroom := TotalHeadroomWatts([]*Power{&power})
+			if !headroom.Present || headroom.Value != test.headroom {
+				t.Errorf("Expected %g W headroom, got %+v", test.headroom, headroom)
+			}
+
+			if matrix := power.RedundancyLossMatrix(); !reflect.DeepEqual(matrix, test.lossMatrix) {
+				t.Errorf("Expected loss matrix %v, got %v", test.lossMatrix, matrix)
+			}
+			if shortfall := power.RedundancyShortfall(); !reflect.DeepEqual(shortfall, test.shortfall) {
+				t.Errorf("Expected shortfall %v, got %v", test.shortfall, shortfall)
+			}
+
+			// A nominal voltage of 0 means none is expected, as for wide
+			// range supplies
+			if nominal, ok := power.PowerSupplies[0].NominalVoltage(); ok != (test.nominalVolts != 0) || nominal != test.nominalVolts {
+				t.Errorf("Expected nominal %g V, got %g (%t)", test.nominalVolts, nominal, ok)
+			}
+			for i := range power.Voltages {
+				if !power.Voltages[i].isNominal() {
+					t.Errorf("Expected voltage %s to be nominal", power.Voltages[i].MemberID)
+				}
+			}
+		})
+	}
+}
+
+// TestGetPowerConcurrent tests that concurrent GetPower calls do not see each
+// other's responses.
+func TestGetPowerConcurrent(t *testing.T) {
+	testClient := &uriClient{bodies: make(map[string]string)}
+	for i := 0; i < 16; i++ {
+		uri := fmt.Sprintf("/redfish/v1/Chassis/%d/Power", i)
+		testClient.bodies[uri] = fmt.Sprintf(`{"@odata.id": %q, "Id": "Power%d"}`, uri, i)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uri := fmt.Sprintf("/redfish/v1/Chassis/%d/Power", i)
+			for j := 0; j < 10; j++ {
+				power, err := GetPower(testClient, uri)
+				if err != nil {
+					t.Errorf("Error getting %s: %s", uri, err)
+					return
+				}
+				if power.ID != fmt.Sprintf("Power%d", i) {
+					t.Errorf("Expected Power%d from %s, got %s", i, uri, power.ID)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestGetPowerInvalidBody tests that a malformed body is reported as a parse
+// error.
+func TestGetPowerInvalidBody(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Power": `{"Id": "Power",`,
+	}}
+
+	_, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	var parseErr *common.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a ParseError, got: %v", err)
+	}
+}
+
+// TestPowerControlSetPowerLimit tests applying and removing a power cap.
+func TestPowerControlSetPowerLimit(t *testing.T) {
+	var result PowerControl
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/1",
+		"MemberId": "1",
+		"PowerLimit": {"LimitInWatts": 800, "LimitException": "NoAction", "CorrectionInMs": 50}
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	limit := 500.0
+	if err := result.SetPowerLimit(&limit, LogEventOnlyPowerLimitException, 100); err != nil {
+		t.Fatalf("Error setting power limit: %s", err)
+	}
+	if err := result.SetPowerLimit(nil, "", 0); err != nil {
+		t.Fatalf("Error removing power limit: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Action != http.MethodPatch || calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected call: %s %s", calls[0].Action, calls[0].URL)
+	}
+	expected := "map[PowerControl:[map[] map[PowerLimit:map[CorrectionInMs:100 LimitException:LogEventOnly LimitInWatts:500]]]]"
+	if calls[0].Payload != expected {
+		t.Errorf("Expected payload %s, got %s", expected, calls[0].Payload)
+	}
+	expected = "map[PowerControl:[map[] map[PowerLimit:map[LimitInWatts:<nil>]]]]"
+	if calls[1].Payload != expected {
+		t.Errorf("Expected payload %s, got %s", expected, calls[1].Payload)
+	}
+	if result.PowerLimit.LimitInWatts != 0 || result.PowerLimit.LimitException != LogEventOnlyPowerLimitException ||
+		result.PowerLimit.CorrectionInMs != 100 {
+		t.Errorf("Unexpected power limit: %+v", result.PowerLimit)
+	}
+
+	testClient = &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {&http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "Base.1.0.PropertyValueNotInList", "message": "Invalid limit"}}`)),
+			}},
+		},
+	}
+	result.SetClient(testClient)
+	err = result.SetPowerLimit(&limit, "", 0)
+	var redfishErr *common.RedfishError
+	if !errors.As(err, &redfishErr) || redfishErr.StatusCode() != http.StatusBadRequest ||
+		!strings.Contains(err.Error(), "Invalid limit") {
+		t.Errorf("Expected the service error, got: %v", err)
+	}
+
+	noIndex := PowerControl{MemberID: "cpu"}
+	noIndex.ODataID = "/redfish/v1/Chassis/1/Power#/PowerControl/cpu"
+	noIndex.SetClient(&common.TestClient{})
+	if err := noIndex.SetPowerLimit(&limit, "", 0); err == nil {
+		t.Error("Expected an error for a control without a position")
+	}
+}
+
+// TestPowerControlUpdate tests committing a changed power limit.
+func TestPowerControlUpdate(t *testing.T) {
+	var result PowerControl
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+		"MemberId": "0",
+		"PowerConsumedWatts": 344,
+		"PowerLimit": {"LimitInWatts": 800, "LimitException": "NoAction", "CorrectionInMs": 50}
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	if err := result.Update(); err != nil {
+		t.Errorf("Error updating unchanged control: %s", err)
+	}
+
+	result.PowerLimit.LimitInWatts = 600
+	if err := result.Update(); err != nil {
+		t.Fatalf("Error updating control: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(calls))
+	}
+	if calls[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Unexpected URL: %s", calls[0].URL)
+	}
+	expected := "map[PowerControl:[map[PowerLimit:map[LimitInWatts:600]]]]"
+	if calls[0].Payload != expected {
+		t.Errorf("Expected payload %s, got %s", expected, calls[0].Payload)
+	}
+
+	result.PowerConsumedWatts = 100
+	if err := result.Update(); err == nil {
+		t.Error("Expected an error updating a read only property")
+	}
+}
+
+// blockingClient is a client whose requests do not complete until release is
+// closed.
+type blockingClient struct {
+	common.TestClient
+	release chan struct{}
+}
+
+// Get blocks until the client is released.
+func (c *blockingClient) Get(url string) (*http.Response, error) {
+	<-c.release
+	return getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power"}`), nil
+}
+
+// TestGetPowerWithContext tests giving up on a request when its context is
+// done.
+func TestGetPowerWithContext(t *testing.T) {
+	testClient := &blockingClient{release: make(chan struct{})}
+	defer close(testClient.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := GetPowerWithContext(ctx, testClient, "/redfish/v1/Chassis/1/Power")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got: %v", err)
+	}
+
+	_, err = ListReferencedPowersWithContext(ctx, testClient, "/redfish/v1/Chassis/1/Powers")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got: %v", err)
+	}
+}
+
+// TestListReferencedPowersWithContext tests that members not fetched before
+// the context is done are reported as failures.
+func TestListReferencedPowersWithContext(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Powers": `{
+			"Members@odata.count": 2,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1/Power"},
+				{"@odata.id": "/redfish/v1/Chassis/2/Power"}
+			]
+		}`,
+		"/redfish/v1/Chassis/1/Power": `{"@odata.id": "/redfish/v1/Chassis/1/Power", "Id": "Power"}`,
+		"/redfish/v1/Chassis/2/Power": `{"@odata.id": "/redfish/v1/Chassis/2/Power", "Id": "Power"}`,
+	}}
+
+	result, err := ListReferencedPowersWithContext(context.Background(), testClient, "/redfish/v1/Chassis/1/Powers")
+	if err != nil || len(result) != 2 {
+		t.Fatalf("Expected 2 powers, got %d: %v", len(result), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ListReferencedPowersWithContext(ctx, testClient, "/redfish/v1/Chassis/1/Powers")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the request to be cancelled, got: %v", err)
+	}
+}
+
+// TestListReferencedPowersExpanded tests reading inlined members of a
+// collection and fetching the ones that are only referenced.
+func TestListReferencedPowersExpanded(t *testing.T) {
+	expanded := common.NewQuery().WithExpandLevels(1).Apply("/redfish/v1/Chassis/1/Powers")
+	testClient := &uriClient{bodies: map[string]string{
+		expanded: `{
+			"Members@odata.count": 3,
+			"Members": [
+				{
+					"@odata.type": "#Power.v1_7_0.Power",
+					"@odata.id": "/redfish/v1/Chassis/1/Power",
+					"@odata.etag": "W/\"1\"",
+					"Id": "Power",
+					"PowerSupplies": [{"MemberId": "0", "PowerCapacityWatts": 800}]
+				},
+				{"@odata.id": "/redfish/v1/Chassis/2/Power"},
+				{"@odata.id": "/redfish/v1/Chassis/3/Power"}
+			]
+		}`,
+		"/redfish/v1/Chassis/2/Power": `{"@odata.id": "/redfish/v1/Chassis/2/Power", "Id": "Power"}`,
+	}}
+
+	result, err := ListReferencedPowersExpanded(testClient, "/redfish/v1/Chassis/1/Powers")
+	if len(result) != 2 || result[0].ODataID != "/redfish/v1/Chassis/1/Power" || result[1].ODataID != "/redfish/v1/Chassis/2/Power" {
+		t.Fatalf("Invalid powers: %v", result)
+	}
+	if len(result[0].PowerSupplies) != 1 || result[0].PowerSupplies[0].etag != `W/"1"` {
+		t.Errorf("Expected the inlined power to be decoded fully: %+v", result[0].PowerSupplies)
+	}
+	if result[0].Client != testClient {
+		t.Errorf("Expected the inlined power to have a client")
+	}
+
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || collectionErr.Failures["/redfish/v1/Chassis/3/Power"] == nil {
+		t.Errorf("Expected the missing power to be reported, got: %v", err)
+	}
+
+	// The collection and the two referenced members
+	if len(testClient.gets) != 3 || testClient.gets[0] != expanded {
+		t.Errorf("Invalid requests: %v", testClient.gets)
+	}
+}
+
+// TestIterateReferencedPowers tests visiting the members of a collection one
+// at a time.
+func TestIterateReferencedPowers(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Powers": `{
+			"Members@odata.count": 3,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1/Power"},
+				{"@odata.id": "/redfish/v1/Chassis/2/Power"},
+				{"@odata.id": "/redfish/v1/Chassis/3/Power"}
+			]
+		}`,
+		"/redfish/v1/Chassis/1/Power": `{"@odata.id": "/redfish/v1/Chassis/1/Power", "Id": "Power"}`,
+		"/redfish/v1/Chassis/3/Power": `{"@odata.id": "/redfish/v1/Chassis/3/Power", "Id": "Power"}`,
+	}}
+
+	var visited []string
+	err := IterateReferencedPowers(context.Background(), testClient, "/redfish/v1/Chassis/1/Powers", func(power *Power) error {
+		visited = append(visited, power.ODataID)
+		return nil
+	})
+	if len(visited) != 2 || visited[0] != "/redfish/v1/Chassis/1/Power" || visited[1] != "/redfish/v1/Chassis/3/Power" {
+		t.Errorf("Invalid powers visited: %v", visited)
+	}
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 {
+		t.Fatalf("Expected the missing power to be reported, got: %v", err)
+	}
+	if _, ok := collectionErr.Failures["/redfish/v1/Chassis/2/Power"]; !ok {
+		t.Errorf("Invalid failures: %v", collectionErr.Failures)
+	}
+
+	// Stop at the first member
+	errStop := errors.New("stop")
+	visited = nil
+	err = IterateReferencedPowers(context.Background(), testClient, "/redfish/v1/Chassis/1/Powers", func(power *Power) error {
+		visited = append(visited, power.ODataID)
+		return errStop
+	})
+	if err != errStop || len(visited) != 1 {
+		t.Errorf("Expected to stop after 1 power, visited %d: %v", len(visited), err)
+	}
+}
+
+// slowClient is a uriClient that takes delay to answer each Power request.
+type slowClient struct {
+	uriClient
+	delay time.Duration
+}
+
+// Get returns the body registered for the URI after the delay.
+func (c *slowClient) Get(url string) (*http.Response, error) {
+	if strings.HasSuffix(url, "/Power") {
+		time.Sleep(c.delay)
+	}
+	return c.uriClient.Get(url)
+}
+
+// TestListReferencedPowersConcurrency tests that members are fetched
+// concurrently and returned in collection order.
+func TestListReferencedPowersConcurrency(t *testing.T) {
+	testClient := &slowClient{delay: 50 * time.Millisecond}
+	testClient.bodies = map[string]string{}
+	var members []string
+	for i := 0; i < 8; i++ {
+		uri := fmt.Sprintf("/redfish/v1/Chassis/%d/Power", i)
+		members = append(members, fmt.Sprintf(`{"@odata.id": %q}`, uri))
+		if i != 5 {
+			testClient.bodies[uri] = fmt.Sprintf(`{"@odata.id": %q, "Id": "Power%d"}`, uri, i)
+		}
+	}
+	testClient.bodies["/redfish/v1/Powers"] = fmt.Sprintf(`{"Members@odata.count": 8, "Members": [%s]}`,
+		strings.Join(members, ", "))
+
+	start := time.Now()
+	result, err := ListReferencedPowersWithConcurrency(context.Background(), testClient, "/redfish/v1/Powers", 4)
+	elapsed := time.Since(start)
+
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 ||
+		collectionErr.Failures["/redfish/v1/Chassis/5/Power"] == nil {
+		t.Errorf("Expected one failure for the missing member, got: %v", err)
+	}
+
+	var ids []string
+	for _, power := range result {
+		ids = append(ids, power.ID)
+	}
+	expected := []string{"Power0", "Power1", "Power2", "Power3", "Power4", "Power6", "Power7"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+
+	// Serial fetches would take 400ms; four at a time should take about 100ms
+	if elapsed >= 8*testClient.delay {
+		t.Errorf("Expected concurrent fetches, took %s", elapsed)
+	}
+}
+
+// TestVoltageThresholdState tests classifying readings against thresholds.
+func TestVoltageThresholdState(t *testing.T) {
+	thresholds := `"LowerThresholdFatal": 10, "LowerThresholdCritical": 11, "LowerThresholdNonCritical": 11.5,
+		"UpperThresholdNonCritical": 12.5, "UpperThresholdCritical": 13, "UpperThresholdFatal": 15`
+	tests := []struct {
+		body     string
+		expected VoltageThresholdState
+	}{
+		{`{"ReadingVolts": 12, ` + thresholds + `}`, NormalVoltageThresholdState},
+		{`{"ReadingVolts": 11.2, ` + thresholds + `}`, LowerNonCriticalVoltageThresholdState},
+		{`{"ReadingVolts": 11, ` + thresholds + `}`, LowerCriticalVoltageThresholdState},
+		{`{"ReadingVolts": 9, ` + thresholds + `}`, LowerFatalVoltageThresholdState},
+		{`{"ReadingVolts": 12.7, ` + thresholds + `}`, UpperNonCriticalVoltageThresholdState},
+		{`{"ReadingVolts": 14, ` + thresholds + `}`, UpperCriticalVoltageThresholdState},
+		{`{"ReadingVolts": 15.1, ` + thresholds + `}`, UpperFatalVoltageThresholdState},
+		// Only the upper threshold is set, so a low reading is not flagged
+		{`{"ReadingVolts": 0.5, "UpperThresholdCritical": 13}`, NormalVoltageThresholdState},
+		// A reported threshold of 0 applies to a negative rail
+		{`{"ReadingVolts": 0.2, "UpperThresholdCritical": 0}`, UpperCriticalVoltageThresholdState},
+		{`{"ReadingVolts": -48, "LowerThresholdCritical": -54, "UpperThresholdCritical": -40}`, NormalVoltageThresholdState},
+		{`{"ReadingVolts": -56, "LowerThresholdCritical": -54, "UpperThresholdCritical": -40}`, LowerCriticalVoltageThresholdState},
+	}
+
+	for _, test := range tests {
+		var voltage Voltage
+		if err := json.Unmarshal([]byte(test.body), &voltage); err != nil {
+			t.Fatalf("Error decoding %s: %s", test.body, err)
+		}
+		if state := voltage.ThresholdState(); state != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.body, test.expected, state)
+		}
+	}
+
+	constructed := Voltage{ReadingVolts: 0.2, UpperThresholdCritical: 0, LowerThresholdCritical: -1}
+	if state := constructed.ThresholdState(); state != NormalVoltageThresholdState {
+		t.Errorf("Expected unset thresholds to be skipped, got %s", state)
+	}
+}
+
+// TestPowerSupplyAssembly tests getting the linked Assembly of a supply.
+func TestPowerSupplyAssembly(t *testing.T) {
+	var result PowerSupply
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+		"MemberId": "0",
+		"Assembly": {"@odata.id": "/redfish/v1/Chassis/1/PowerSupplies/0/Assembly"}
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	result.SetClient(&uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/PowerSupplies/0/Assembly": `{
+			"@odata.id": "/redfish/v1/Chassis/1/PowerSupplies/0/Assembly",
+			"@odata.type": "#Assembly.v1_2_1.Assembly",
+			"Id": "Assembly",
+			"Assemblies": [{"MemberId": "0", "Model": "PSU-800", "SerialNumber": "1Z0000001"}]
+		}`,
+	}})
+
+	assembly, err := result.Assembly()
+	if err != nil {
+		t.Fatalf("Error getting assembly: %s", err)
+	}
+	if len(assembly.Assemblies) != 1 || assembly.Assemblies[0].SerialNumber != "1Z0000001" {
+		t.Errorf("Invalid assembly: %+v", assembly.Assemblies)
+	}
+
+	var unlinked PowerSupply
+	if assembly, err := unlinked.Assembly(); assembly != nil || err != nil {
+		t.Errorf("Expected no assembly without a link, got %v, %v", assembly, err)
+	}
+}
+
+// TestPowerSupplyRedundancyGroups tests resolving the redundancy groups of a
+// supply from their links.
+func TestPowerSupplyRedundancyGroups(t *testing.T) {
+	var result PowerSupply
+	err := json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+		"MemberId": "0",
+		"Redundancy": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/1"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/7"},
+			{"@odata.id": "/redfish/v1/Systems/1/Redundancy/Fabric"}
+		]
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Power": `{
+			"@odata.id": "/redfish/v1/Chassis/1/Power",
+			"Redundancy": [
+				{"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0", "MemberId": "0", "Name": "PSU Group A", "Mode": "N+m", "MinNumNeeded": 1},
+				{"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/1", "MemberId": "1", "Name": "PSU Group B", "Mode": "Sparing", "MinNumNeeded": 1}
+			]
+		}`,
+		"/redfish/v1/Systems/1/Redundancy/Fabric": `{
+			"@odata.id": "/redfish/v1/Systems/1/Redundancy/Fabric",
+			"Name": "Fabric",
+			"Mode": "Failover"
+		}`,
+	}}
+	result.SetClient(testClient)
+
+	groups, err := result.RedundancyGroups()
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 ||
+		collectionErr.Failures["/redfish/v1/Chassis/1/Power#/Redundancy/7"] == nil {
+		t.Errorf("Expected one failure for the missing group, got: %v", err)
+	}
+
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"PSU Group A", "PSU Group B", "Fabric"}) {
+		t.Errorf("Invalid groups: %v", names)
+	}
+	if groups[1].Mode != SparingRedundancyMode {
+		t.Errorf("Invalid mode: %s", groups[1].Mode)
+	}
+
+	if len(testClient.gets) != 2 {
+		t.Errorf("Expected the Power resource to be fetched once, got %v", testClient.gets)
+	}
+}
+
+// TestPowerSupplyUpdateIfMatch tests that updates are conditional on the ETag.
+func TestPowerSupplyUpdateIfMatch(t *testing.T) {
+	resp := getCall(indicatorPowerBody(common.OffIndicatorLED))
+	resp.Header = http.Header{}
+	resp.Header.Set("ETag", `W/"abc"`)
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {resp},
+		},
+	}
+
+	result, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	supply := &result.PowerSupplies[0]
+	supply.IndicatorLED = common.LitIndicatorLED
+	err = supply.Update()
+	if err != nil {
+		t.Errorf("Error making update: %s", err)
+	}
+
+	supply.IndicatorLED = common.BlinkingIndicatorLED
+	err = supply.ForceUpdate()
+	if err != nil {
+		t.Errorf("Error making forced update: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 3 {
+		t.Fatalf("Expected GET and two PATCH calls, captured: %v", calls)
+	}
+	if calls[1].CustomHeaders["If-Match"] != `W/"abc"` {
+		t.Errorf("Expected If-Match with the ETag, got: %v", calls[1].CustomHeaders)
+	}
+	if calls[2].CustomHeaders["If-Match"] != "*" {
+		t.Errorf("Expected If-Match: * on forced update, got: %v", calls[2].CustomHeaders)
+	}
+}
+
+// TestPowerSupplyUpdateODataEtag tests falling back to the @odata.etag property.
+func TestPowerSupplyUpdateODataEtag(t *testing.T) {
+	var supply PowerSupply
+	err := json.Unmarshal([]byte(`{"@odata.id": "/redfish/v1/PowerSupplies/1", "@odata.etag": "\"1\"", "IndicatorLED": "Off"}`), &supply)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	supply.SetClient(testClient)
+	supply.IndicatorLED = common.LitIndicatorLED
+	err = supply.Update()
+	if err != nil {
+		t.Errorf("Error making update: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].CustomHeaders["If-Match"] != `"1"` {
+		t.Errorf("Expected If-Match from @odata.etag, captured: %v", calls)
+	}
+}
+
+// TestPowerSupplyUpdateRejected tests that a rejected update returns the service error.
+func TestPowerSupplyUpdateRejected(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(indicatorPowerBody(common.OffIndicatorLED))).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	body := `{"error": {"code": "Base.1.0.GeneralError", "@Message.ExtendedInfo": [{"MessageId": "Base.1.0.PropertyNotWritable"}]}}`
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPatch: {
+				&http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(strings.NewReader(body)),
+				},
+			},
+		},
+	}
+
+	supply := &result.PowerSupplies[0]
+	supply.SetClient(testClient)
+	supply.IndicatorLED = common.LitIndicatorLED
+
+	err = supply.Update()
+	var redfishErr *common.RedfishError
+	if !errors.As(err, &redfishErr) {
+		t.Fatalf("Expected *common.RedfishError, got: %v", err)
+	}
+
+	if redfishErr.StatusCode() != http.StatusBadRequest ||
+		!redfishErr.HasMessageID("Base.1.0.PropertyNotWritable") {
+		t.Errorf("Invalid service error: %v", redfishErr)
+	}
+}
+
+var oemPowerBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Oem": {"Hpe": {"PowerMeter": {"Average": 250}}},
+		"PowerControl": [
+			{
+				"MemberId": "0",
+				"Oem": {"Dell": {"EnergyConsumptionkWh": 12.5}}
+			}
+		],
+		"PowerSupplies": [
+			{
+				"MemberId": "0",
+				"Oem": {"Vendor": {"ManufactureDate": "2020-01-02"}}
+			},
+			{
+				"MemberId": "1"
+			}
+		]
+	}`
+
+// TestPowerGetOem tests decoding the raw Oem properties.
+func TestPowerGetOem(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(oemPowerBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	var powerOem struct {
+		Hpe struct {
+			PowerMeter struct {
+				Average float64
+			}
+		}
+	}
+	if err := result.GetOem(&powerOem); err != nil || powerOem.Hpe.PowerMeter.Average != 250 {
+		t.Errorf("Invalid power Oem: %v %v", powerOem, err)
+	}
+
+	var controlOem struct {
+		Dell struct {
+			EnergyConsumptionkWh float64
+		}
+	}
+	if err := result.PowerControl[0].GetOem(&controlOem); err != nil || controlOem.Dell.EnergyConsumptionkWh != 12.5 {
+		t.Errorf("Invalid power control Oem: %v %v", controlOem, err)
+	}
+
+	var supplyOem struct {
+		Vendor struct {
+			ManufactureDate string
+		}
+	}
+	if err := result.PowerSupplies[0].GetOem(&supplyOem); err != nil || supplyOem.Vendor.ManufactureDate != "2020-01-02" {
+		t.Errorf("Invalid power supply Oem: %v %v", supplyOem, err)
+	}
+
+	if result.PowerSupplies[0].ManufactureDate.IsZero() {
+		t.Error("Expected ManufactureDate to still be read from the Oem properties")
+	}
+
+	supplyOem.Vendor.ManufactureDate = "unchanged"
+	if err := result.PowerSupplies[1].GetOem(&supplyOem); err != nil || supplyOem.Vendor.ManufactureDate != "unchanged" {
+		t.Errorf("Expected target to be left unchanged without Oem: %v %v", supplyOem, err)
+	}
+}
+
+// TestPowerChassis tests navigating from a power resource to its chassis.
+func TestPowerChassis(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(chassisBody)},
+		},
+	}
+
+	result := &Power{}
+	result.ODataID = "/redfish/v1/Chassis/Chassis-1/Power/"
+	result.SetClient(testClient)
+
+	chassis, err := result.Chassis()
+	if err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+	if chassis.ODataID != "/redfish/v1/Chassis/Chassis-1" || chassis.ID != "Chassis-1" || chassis.SKU != "8675309" {
+		t.Errorf("Invalid chassis: %s, %s", chassis.ODataID, chassis.ID)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Chassis/Chassis-1" {
+		t.Errorf("Expected GET of the parent chassis, captured: %v", calls)
+	}
+
+	for _, uri := range []string{
+		"",
+		"/redfish/v1/Chassis/Chassis-1/Thermal",
+		"/redfish/v1/Systems/1/Power",
+		"/redfish/v1/Chassis/Power",
+	} {
+		result.ODataID = uri
+		if _, err := result.Chassis(); err == nil {
+			t.Errorf("Expected an error for %q", uri)
+		}
+	}
+
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Expected no request for malformed URIs, captured: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestPowerStringWatts tests watt readings given as strings or numbers.
+func TestPowerStringWatts(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerControl": [
+			{
+				"MemberId": "0",
+				"PowerConsumedWatts": "123.4",
+				"PowerMetrics": {
+					"AverageConsumedWatts": "110.5",
+					"MaxConsumedWatts": 150,
+					"MinConsumedWatts": "90"
+				}
+			}
+		],
+		"PowerSupplies": [
+			{
+				"MemberId": "0",
+				"LastPowerOutputWatts": "61",
+				"PowerCapacityWatts": "750",
+				"PowerInputWatts": 70.5,
+				"PowerOutputWatts": "61.25"
+			}
+		]
+	}`
+
+	var result Power
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	control := result.PowerControl[0]
+	if control.PowerConsumedWatts != 123.4 {
+		t.Errorf("Invalid PowerConsumedWatts: %v", control.PowerConsumedWatts)
+	}
+	metrics := control.PowerMetrics
+	if metrics.AverageConsumedWatts != 110.5 || metrics.MaxConsumedWatts != 150 || metrics.MinConsumedWatts != 90 {
+		t.Errorf("Invalid PowerMetrics: %+v", metrics)
+	}
+
+	supply := result.PowerSupplies[0]
+	if supply.LastPowerOutputWatts != 61 || supply.PowerCapacityWatts != 750 ||
+		supply.PowerInputWatts != 70.5 || supply.PowerOutputWatts != 61.25 {
+		t.Errorf("Invalid power supply watts: %v %v %v %v", supply.LastPowerOutputWatts,
+			supply.PowerCapacityWatts, supply.PowerInputWatts, supply.PowerOutputWatts)
+	}
+}
+
+// TestPowerMetricIntervalMinutes tests rounding the metric interval.
+func TestPowerMetricIntervalMinutes(t *testing.T) {
+	tests := []struct {
+		interval float64
+		expected int
+	}{
+		{5, 5},
+		{5.0000001, 5},
+		{4.9999999, 5},
+		{1.5, 2},
+		{0.4, 0},
+		{0, 0},
+	}
+
+	for _, test := range tests {
+		metric := PowerMetric{IntervalInMin: test.interval}
+		if metric.IntervalMinutes() != test.expected {
+			t.Errorf("IntervalInMin %v, expected %d minutes, got %d",
+				test.interval, test.expected, metric.IntervalMinutes())
+		}
+	}
+}
+
+// TestPowerSupplySupportsVoltage tests matching line voltages against input ranges.
+func TestPowerSupplySupportsVoltage(t *testing.T) {
+	supply := PowerSupply{
+		InputRanges: []InputRange{
+			{InputType: ACInputType, MinimumVoltage: 100, MaximumVoltage: 127},
+			{InputType: ACInputType, MinimumVoltage: 200, MaximumVoltage: 240},
+			{InputType: DCInputType, MinimumVoltage: -40, MaximumVoltage: -60},
+		},
+	}
+
+	tests := []struct {
+		volts     float64
+		inputType InputType
+		expected  bool
+	}{
+		{120, ACInputType, true},
+		{100, ACInputType, true},
+		{240, ACInputType, true},
+		{230, ACInputType, true},
+		{160, ACInputType, false},
+		{277, ACInputType, false},
+		{120, DCInputType, false},
+		{-48, DCInputType, true},
+		{48, DCInputType, true},
+		{-12, DCInputType, false},
+	}
+
+	for _, test := range tests {
+		if supply.SupportsVoltage(test.volts, test.inputType) != test.expected {
+			t.Errorf("%g V %s: expected %t", test.volts, test.inputType, test.expected)
+		}
+	}
+
+	if (&PowerSupply{}).SupportsVoltage(120, ACInputType) {
+		t.Error("Expected no support without input ranges")
+	}
+}
+
+// TestGetPowerSelect tests requesting a subset of the properties.
+func TestGetPowerSelect(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(`{
+					"@odata.id": "/redfish/v1/Chassis/1/Power",
+					"@odata.type": "#Power.v1_5_3.Power",
+					"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 344}]
+				}`),
+				// A service that ignores $select
+				getCall(mockupRackmountPowerBody),
+			},
+		},
+	}
+
+	result, err := GetPowerSelect(testClient, "/redfish/v1/Chassis/1/Power", []string{"PowerControl"})
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Chassis/1/Power?$select=PowerControl" {
+		t.Errorf("Expected a GET with $select, captured: %v", calls)
+	}
+
+	if len(result.PowerControl) != 1 || result.PowerControl[0].PowerConsumedWatts != 344 {
+		t.Errorf("Invalid PowerControl: %+v", result.PowerControl)
+	}
+	if len(result.PowerSupplies) != 0 || len(result.Voltages) != 0 {
+		t.Errorf("Expected unselected properties to be empty: %d supplies, %d voltages",
+			len(result.PowerSupplies), len(result.Voltages))
+	}
+
+	result, err = GetPowerSelect(testClient, "/redfish/v1/Chassis/1/Power", []string{"PowerControl"})
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if len(result.PowerControl) == 0 || len(result.PowerSupplies) == 0 {
+		t.Errorf("Expected the full resource to be decoded when $select is ignored")
+	}
+}
+
+// TestVoltageMillivolts tests converting voltage readings to millivolts.
+func TestVoltageMillivolts(t *testing.T) {
+	voltage := Voltage{
+		ReadingVolts:              3.3,
+		LowerThresholdNonCritical: 3.135,
+		LowerThresholdCritical:    3.1,
+		UpperThresholdNonCritical: 3.465,
+		UpperThresholdCritical:    3.5,
+		UpperThresholdFatal:       12.006,
+	}
+
+	if voltage.ReadingMillivolts() != 3300 {
+		t.Errorf("Invalid reading: %v", voltage.ReadingMillivolts())
+	}
+
+	expected := VoltageThresholds{
+		LowerNonCritical: 3135,
+		LowerCritical:    3100,
+		UpperNonCritical: 3465,
+		UpperCritical:    3500,
+		UpperFatal:       12006,
+	}
+	if voltage.ThresholdsMillivolts() != expected {
+		t.Errorf("Invalid thresholds: %+v", voltage.ThresholdsMillivolts())
+	}
+
+	voltage.ReadingVolts = -48.25
+	if voltage.ReadingMillivolts() != -48250 {
+		t.Errorf("Invalid negative reading: %v", voltage.ReadingMillivolts())
+	}
+}
+
+// TestPowerByContext tests selecting power controls and voltages by context.
+func TestPowerByContext(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(mixedContextPowerBody)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	voltages := result.VoltagesByContext(common.CPUPhysicalContext)
+	if len(voltages) != 1 || voltages[0].MemberID != "0" {
+		t.Errorf("Invalid CPU voltages: %+v", voltages)
+	}
+
+	// Vendor spellings are normalized
+	voltages = result.VoltagesByContext("system_board")
+	if len(voltages) != 1 || voltages[0].MemberID != "1" {
+		t.Errorf("Invalid system board voltages: %+v", voltages)
+	}
+
+	controls := result.PowerControlByContext(common.CPUPhysicalContext)
+	if len(controls) != 1 || controls[0].MemberID != "0" {
+		t.Errorf("Invalid CPU power controls: %+v", controls)
+	}
+
+	if controls := result.PowerControlByContext(common.MemoryPhysicalContext); len(controls) != 0 {
+		t.Errorf("Expected no memory power controls, got %+v", controls)
+	}
+
+	// A context that is not a string is treated as not reported
+	var voltage Voltage
+	err = json.Unmarshal([]byte(`{"MemberId": "2", "PhysicalContext": 7, "ReadingVolts": 12}`), &voltage)
+	if err != nil {
+		t.Fatalf("Error decoding voltage: %s", err)
+	}
+	if voltage.PhysicalContext != "" || voltage.ReadingVolts != 12 {
+		t.Errorf("Invalid voltage: %+v", voltage)
+	}
+
+	// The context can still be read as a string
+	if string(result.Voltages[0].PhysicalContext) != "CPU" {
+		t.Errorf("Invalid context string: %s", result.Voltages[0].PhysicalContext)
+	}
+}
+
+// TestPowerMockClient tests reading and updating power through a MockClient.
+func TestPowerMockClient(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", indicatorPowerBody(common.OffIndicatorLED))
+	c.SetBody("/redfish/v1/Chassis", `{
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power"},
+			{"@odata.id": "/redfish/v1/Chassis/2/Power"}
+		]
+	}`)
+
+	powers, err := ListReferencedPowers(c, "/redfish/v1/Chassis")
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 {
+		t.Errorf("Expected one failure for the missing power resource, got: %v", err)
+	}
+	if len(powers) != 1 {
+		t.Fatalf("Expected 1 power resource, got %d", len(powers))
+	}
+
+	supply := &powers[0].PowerSupplies[0]
+	supply.IndicatorLED = common.LitIndicatorLED
+	if err := supply.Update(); err != nil {
+		t.Errorf("Error updating power supply: %s", err)
+	}
+
+	// The embedded supply is changed through the array of its parent
+	patches := c.PatchBodies("/redfish/v1/Chassis/1/Power")
+	if len(patches) != 1 || patches[0] != `{"PowerSupplies":[{"IndicatorLED":"Lit"}]}` {
+		t.Errorf("Invalid patch bodies: %v", patches)
+	}
+
+	c.SetError(http.MethodPatch, "/redfish/v1/Chassis/1/Power", http.StatusBadRequest,
+		`{"error": {"@Message.ExtendedInfo": [{"MessageId": "Base.1.0.PropertyValueNotInList"}]}}`)
+	supply.IndicatorLED = common.BlinkingIndicatorLED
+	err = supply.Update()
+	var redfishErr *common.RedfishError
+	if !errors.As(err, &redfishErr) || !redfishErr.HasMessageID("Base.1.0.PropertyValueNotInList") {
+		t.Errorf("Expected the simulated error, got: %v", err)
+	}
+}
+
+// TestPowerRefresh tests re-reading the telemetry of a power resource in place.
+func TestPowerRefresh(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Description": "Original",
+		"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 300}],
+		"Voltages": [{"MemberId": "0", "ReadingVolts": 12}]
+	}`)
+
+	result, err := GetPower(c, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	result.Description = "Local"
+
+	c.SetResponse(http.MethodGet, "/redfish/v1/Chassis/1/Power", common.MockResponse{
+		Body: `{
+			"@odata.id": "/redfish/v1/Chassis/1/Power",
+			"Description": "Changed",
+			"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 450}],
+			"Voltages": [{"MemberId": "0", "ReadingVolts": 11.9}]
+		}`,
+		Header: http.Header{"Etag": []string{`"2"`}},
+	})
+
+	if err := result.Refresh(); err != nil {
+		t.Fatalf("Error refreshing power: %s", err)
+	}
+
+	if result.PowerControl[0].PowerConsumedWatts != 450 || result.Voltages[0].ReadingVolts != 11.9 {
+		t.Errorf("Telemetry not refreshed: %+v, %+v", result.PowerControl[0], result.Voltages[0])
+	}
+	if result.Description != "Local" {
+		t.Errorf("Expected other properties to be kept, got %q", result.Description)
+	}
+	if result.etag != `"2"` || result.Client != c {
+		t.Errorf("Invalid etag or client after refresh: %s", result.etag)
+	}
+
+	c.SetError(http.MethodGet, "/redfish/v1/Chassis/1/Power", http.StatusNotFound, "{}")
+	err = result.Refresh()
+	var redfishErr *common.RedfishError
+	if !errors.As(err, &redfishErr) || redfishErr.StatusCode() != http.StatusNotFound ||
+		!strings.Contains(err.Error(), "no longer exists") {
+		t.Errorf("Expected a removed resource error, got: %v", err)
+	}
+	if result.PowerControl[0].PowerConsumedWatts != 450 {
+		t.Error("Expected the telemetry to be kept after a failed refresh")
+	}
+}
+
+// TestPowerMarshalRoundTrip tests that a marshaled power resource decodes
+// back into the same resource.
+func TestPowerMarshalRoundTrip(t *testing.T) {
+	body := strings.Replace(mockupRackmountPowerBody, `"MemberId": "0",
+			"Name": "Server Power Control",
+			"PowerConsumedWatts": 344,`, `"MemberId": 0,
+			"Name": "Server Power Control",`, 1)
+
+	var original Power
+	if err := json.Unmarshal([]byte(body), &original); err != nil {
+		t.Fatalf("Error decoding fixture: %s", err)
+	}
+	original.SetClient(common.NewMockClient())
+
+	data, err := json.Marshal(&original)
+	if err != nil {
+		t.Fatalf("Error marshaling power: %s", err)
+	}
+	for _, property := range []string{
+		`"PowerControl@odata.count":1`,
+		`"PowerSupplies@odata.count":2`,
+		`"Voltages@odata.count":2`,
+		`"MemberId":"0"`,
+	} {
+		if !strings.Contains(string(data), property) {
+			t.Errorf("Expected %s in %s", property, data)
+		}
+	}
+
+	var result Power
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Error decoding marshaled power: %s", err)
+	}
+
+	if result.ODataType != original.ODataType || result.ID != original.ID {
+		t.Errorf("Invalid identity: %s %s", result.ODataType, result.ID)
+	}
+	if len(result.PowerControl) != 1 || len(result.PowerSupplies) != 2 || len(result.Voltages) != 2 {
+		t.Fatalf("Invalid arrays: %d %d %d", len(result.PowerControl), len(result.PowerSupplies), len(result.Voltages))
+	}
+
+	control := result.PowerControl[0]
+	if control.MemberID != "0" || control.PowerCapacityWatts != 800 ||
+		control.PowerMetrics != original.PowerControl[0].PowerMetrics ||
+		control.PowerLimit != original.PowerControl[0].PowerLimit {
+		t.Errorf("Invalid power control: %+v", control)
+	}
+	if control.ConsumedWatts().Present {
+		t.Error("Expected the unreported consumption to stay unreported")
+	}
+
+	for i := range result.Voltages {
+		if result.Voltages[i].ThresholdState() != original.Voltages[i].ThresholdState() ||
+			result.Voltages[i].reportedThresholds != original.Voltages[i].reportedThresholds {
+			t.Errorf("Invalid voltage %d: %+v", i, result.Voltages[i])
+		}
+		result.Voltages[i].Client = nil
+		original.Voltages[i].Client = nil
+		if !reflect.DeepEqual(result.Voltages[i], original.Voltages[i]) {
+			t.Errorf("Voltage %d differs after round trip: %+v", i, result.Voltages[i])
+		}
+	}
+	if result.PowerSupplies[1].PowerCapacityWatts != original.PowerSupplies[1].PowerCapacityWatts {
+		t.Errorf("Invalid power supply: %+v", result.PowerSupplies[1])
+	}
+}
+
+// TestPowerThermal tests fetching the Thermal resource of the same chassis.
+func TestPowerThermal(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/Chassis-1", chassisBody)
+	c.SetBody("/redfish/v1/Chassis/Chassis-1/Thermal", thermalBody)
+	c.SetBody("/redfish/v1/Chassis/2", `{
+		"@odata.id": "/redfish/v1/Chassis/2",
+		"Id": "2",
+		"Power": {"@odata.id": "/redfish/v1/Chassis/2/Power"}
+	}`)
+
+	result := &Power{}
+	result.ODataID = "/redfish/v1/Chassis/Chassis-1/Power"
+	result.SetClient(c)
+
+	thermal, err := result.Thermal()
+	if err != nil {
+		t.Fatalf("Error getting thermal: %s", err)
+	}
+	if thermal.ID != "Thermal-1" {
+		t.Errorf("Invalid thermal: %s", thermal.ID)
+	}
+
+	result.ODataID = "/redfish/v1/Chassis/2/Power"
+	if _, err := result.Thermal(); err == nil || !strings.Contains(err.Error(), "no Thermal resource") {
+		t.Errorf("Expected an error for a chassis without thermal, got: %v", err)
+	}
+
+	// A chassis without a Power link, read through a TestClient
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(`{
+					"@odata.id": "/redfish/v1/Chassis/1U",
+					"Id": "1U",
+					"PowerSubsystem": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem"},
+					"Thermal": {"@odata.id": "/redfish/v1/Chassis/1U/Thermal"}
+				}`),
+				getCall(thermalBody),
+			},
+		},
+	}
+	result.ODataID = "/redfish/v1/Chassis/1U/Power"
+	result.SetClient(testClient)
+	thermal, err = result.Thermal()
+	if err != nil {
+		t.Fatalf("Error getting thermal: %s", err)
+	}
+	if thermal.ID != "Thermal-1" {
+		t.Errorf("Invalid thermal: %s", thermal.ID)
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[0].URL != "/redfish/v1/Chassis/1U" || calls[1].URL != "/redfish/v1/Chassis/1U/Thermal" {
+		t.Errorf("Unexpected requests: %v", calls)
+	}
+}
+
+// TestLineInputVoltageType tests recognizing line input voltage types and
+// mapping them to their nominal voltage.
+func TestLineInputVoltageType(t *testing.T) {
+	tests := []struct {
+		voltageType LineInputVoltageType
+		known       bool
+		nominal     float64
+		ok          bool
+	}{
+		{AC120VLineInputVoltageType, true, 120, true},
+		{AC240VLineInputVoltageType, true, 240, true},
+		{ACMidLineLineInputVoltageType, true, 230, true},
+		{DCNeg48VLineInputVoltageType, true, -48, true},
+		{ACWideRangeLineInputVoltageType, true, 0, false},
+		{UnknownLineInputVoltageType, true, 0, false},
+		{"AC200VHighEfficiency", false, 0, false},
+		{"", false, 0, false},
+	}
+
+	for _, test := range tests {
+		if known := test.voltageType.IsKnown(); known != test.known {
+			t.Errorf("%q: expected known %t, got %t", test.voltageType, test.known, known)
+		}
+		nominal, ok := test.voltageType.NominalVolts()
+		if nominal != test.nominal || ok != test.ok {
+			t.Errorf("%q: expected %v, %t, got %v, %t", test.voltageType, test.nominal, test.ok, nominal, ok)
+		}
+	}
+
+	var supply PowerSupply
+	if err := json.Unmarshal([]byte(`{"LineInputVoltageType": "AC200VHighEfficiency"}`), &supply); err != nil {
+		t.Fatalf("Error decoding power supply: %s", err)
+	}
+	if supply.LineInputVoltageType != "AC200VHighEfficiency" || supply.LineInputVoltageType.IsKnown() {
+		t.Errorf("Expected the vendor value to be kept, got %q", supply.LineInputVoltageType)
+	}
+}
+
+// TestPowerSupplyTotals tests summing the readings of the power supplies.
+func TestPowerSupplyTotals(t *testing.T) {
+	var result Power
+	err := json.Unmarshal([]byte(`{
+		"PowerSupplies": [
+			{"MemberId": "0", "PowerOutputWatts": 400, "PowerInputWatts": 440, "EfficiencyPercent": 94},
+			{"MemberId": "1", "PowerOutputWatts": 300, "PowerInputWatts": 375},
+			{"MemberId": "2", "PowerOutputWatts": 0, "PowerInputWatts": 0},
+			{"MemberId": "3"},
+			{"MemberId": "4", "PowerOutputWatts": 500, "PowerInputWatts": 550, "EfficiencyPercent": 90,
+				"Status": {"State": "Absent"}}
+		]
+	}`), &result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if total := result.TotalOutputWatts(); total != 700 {
+		t.Errorf("Invalid total output: %g", total)
+	}
+	if total := result.TotalInputWatts(); total != 815 {
+		t.Errorf("Invalid total input: %g", total)
+	}
+	// (94 + 300/375*100) / 2
+	if average := result.AverageEfficiencyPercent(); math.Abs(average-87) > 1e-9 {
+		t.Errorf("Invalid average efficiency: %g", average)
+	}
+
+	empty := &Power{PowerSupplies: []PowerSupply{{MemberID: "0"}}}
+	if empty.TotalOutputWatts() != 0 || empty.TotalInputWatts() != 0 || empty.AverageEfficiencyPercent() != 0 {
+		t.Error("Expected zero totals without readings")
+	}
+}
+
+// TestPowerSupplyPresence tests reporting whether supplies are installed and
+// where.
+func TestPowerSupplyPresence(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"PowerSupplies": [
+			{
+				"MemberId": "0",
+				"Status": {"State": "Enabled", "Health": "OK"},
+				"Location": {"PartLocation": {"LocationType": "Bay", "LocationOrdinalValue": 1, "ServiceLabel": "PSU 1"}}
+			},
+			{
+				"MemberId": "1",
+				"Status": {"State": "Absent"},
+				"Location": {"PartLocation": {"LocationType": "Bay", "LocationOrdinalValue": 2, "ServiceLabel": "PSU 2"}}
+			},
+			{"MemberId": "2"}
+		]
+	}`)).Decode(&result)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	tests := []struct {
+		present bool
+		slot    int
+		located bool
+	}{
+		{true, 1, true},
+		{false, 2, true},
+		{true, 0, false},
+	}
+
+	for i, test := range tests {
+		supply := &result.PowerSupplies[i]
+		if supply.IsPresent() != test.present || supply.IsAbsent() == test.present {
+			t.Errorf("Supply %d: expected present %t", i, test.present)
+		}
+		if slot, ok := supply.Slot(); slot != test.slot || ok != test.located {
+			t.Errorf("Supply %d: expected slot %d (%t), got %d (%t)", i, test.slot, test.located, slot, ok)
+		}
+	}
+}
+
+// TestPowerSetSupplyIndicatorLEDs tests setting several indicator LEDs with as
+// few requests as possible.
+func TestPowerSetSupplyIndicatorLEDs(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", `{
+		"@odata.type": "#Power.v1_7_1.Power",
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerSupplies": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0", "MemberId": "0", "IndicatorLED": "Off"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1", "MemberId": "1", "IndicatorLED": "Off"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/2", "MemberId": "2", "IndicatorLED": "Off"},
+			{"@odata.id": "/redfish/v1/Chassis/1/PowerSupplies/3", "MemberId": "3", "IndicatorLED": "Off"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/4", "MemberId": "4"}
+		]
+	}`)
+	c.SetError(http.MethodPatch, "/redfish/v1/Chassis/1/PowerSupplies/3", http.StatusInternalServerError, "")
+
+	power, err := GetPower(c, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	// Invalid requests are rejected without sending anything
+	if err := power.SetSupplyIndicatorLEDs(map[string]common.IndicatorLED{"0": common.BlinkingIndicatorLED, "9": common.LitIndicatorLED}); err == nil {
+		t.Error("Expected an unknown power supply to be rejected")
+	}
+	err = power.SetSupplyIndicatorLEDs(map[string]common.IndicatorLED{"4": common.LitIndicatorLED})
+	if !errors.Is(err, common.ErrUpdateNotSupported) {
+		t.Errorf("Expected an uncontrollable LED to be rejected, got: %v", err)
+	}
+	if len(c.Calls()) != 1 {
+		t.Fatalf("Expected no requests, got %v", c.Calls()[1:])
+	}
+
+	err = power.SetSupplyIndicatorLEDs(map[string]common.IndicatorLED{
+		"0": common.BlinkingIndicatorLED,
+		"2": common.LitIndicatorLED,
+		"3": common.BlinkingIndicatorLED,
+	})
+
+	var partialErr *PartialUpdateError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("Expected a PartialUpdateError, got: %v", err)
+	}
+	if strings.Join(partialErr.Updated, ",") != "0,2" || len(partialErr.Failures) != 1 || partialErr.Failures["3"] == nil {
+		t.Errorf("Invalid partial failure: %+v", partialErr)
+	}
+
+	patches := c.PatchBodies("/redfish/v1/Chassis/1/Power")
+	expected := `{"PowerSupplies":[{"IndicatorLED":"Blinking"},{},{"IndicatorLED":"Lit"}]}`
+	if len(patches) != 1 || patches[0] != expected {
+		t.Errorf("Expected a single PATCH %s, got %v", expected, patches)
+	}
+	if power.PowerSupplies[0].IndicatorLED != common.BlinkingIndicatorLED || power.PowerSupplies[1].IndicatorLED != common.OffIndicatorLED {
+		t.Errorf("Expected the changed LEDs to be recorded")
+	}
+
+	// A resource without a client fails rather than panicking
+	power.Client = nil
+	if err := power.SetSupplyIndicatorLEDs(map[string]common.IndicatorLED{"0": common.OffIndicatorLED}); err == nil {
+		t.Error("Expected an error without a client")
+	}
+}
+
+// TestParsePower tests decoding Power resources without a client.
+func TestParsePower(t *testing.T) {
+	power, err := ParsePower([]byte(`{
+		"@odata.type": "#Power.v1_7_1.Power",
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerControl": {"MemberId": 0, "PowerConsumedWatts": "344"},
+		"PowerSupplies": [{"MemberId": "0", "PowerCapacityWatts": 800}, "corrupt"]
+	}`))
+	if err != nil {
+		t.Fatalf("Error parsing power: %s", err)
+	}
+	if len(power.PowerControl) != 1 || power.PowerControl[0].MemberID != "0" || power.PowerControl[0].PowerConsumedWatts != 344 {
+		t.Errorf("Invalid PowerControl: %+v", power.PowerControl)
+	}
+	if len(power.PowerSupplies) != 1 || len(power.DecodeWarnings) != 1 {
+		t.Errorf("Expected the corrupt supply to be skipped: %d supplies, %v", len(power.PowerSupplies), power.DecodeWarnings)
+	}
+	if err := power.Refresh(); !errors.Is(err, common.ErrNoClient) {
+		t.Errorf("Expected refreshing to fail without a client, got: %v", err)
+	}
+
+	var parseErr *common.ParseError
+	if _, err := ParsePower([]byte(`{"@odata.type": "#Thermal.v1_0_0.Thermal"}`)); !errors.As(err, &parseErr) {
+		t.Errorf("Expected a ParseError for another resource, got: %v", err)
+	}
+
+	control, err := ParsePowerControl([]byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/1",
+		"MemberId": 1,
+		"PowerCapacityWatts": "800"
+	}`))
+	if err != nil || control.MemberID != "1" || control.PowerCapacityWatts != 800 {
+		t.Fatalf("Invalid power control %+v: %v", control, err)
+	}
+	limit := 500.0
+	if err := control.SetPowerLimit(&limit, "", 0); !errors.Is(err, common.ErrNoClient) {
+		t.Errorf("Expected updating to fail without a client, got: %v", err)
+	}
+
+	voltage, err := ParseVoltage([]byte(`{"MemberId": "0", "Name": "VRM1", "ReadingVolts": 1.8}`))
+	if err != nil || voltage.Name != "VRM1" || voltage.ReadingVolts != 1.8 {
+		t.Errorf("Invalid voltage %+v: %v", voltage, err)
+	}
+	if _, err := ParseVoltage([]byte(`{"ReadingVolts": true}`)); !errors.As(err, &parseErr) {
+		t.Errorf("Expected a ParseError, got: %v", err)
+	}
+}
+
+// TestPowerActions tests reading the standard and vendor specific actions of
+// a Power resource.
+func TestPowerActions(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", `{
+		"@odata.type": "#Power.v1_7_1.Power",
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Actions": {
+			"#Power.PowerSupplyReset": {
+				"target": "/redfish/v1/Chassis/1/Power/Actions/Power.PowerSupplyReset",
+				"ResetType@Redfish.AllowableValues": ["ForceRestart"]
+			},
+			"Oem": {
+				"#Oem.Dell.ResetPowerBudget": {
+					"target": "/redfish/v1/Chassis/1/Power/Actions/Oem/Dell.ResetPowerBudget",
+					"@Redfish.ActionInfo": "/redfish/v1/Chassis/1/Power/ResetPowerBudgetActionInfo"
+				}
+			}
+		}
+	}`)
+
+	power, err := GetPower(c, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	actions, err := power.OemActions()
+	if err != nil {
+		t.Fatalf("Error getting OEM actions: %s", err)
+	}
+	budget := actions["#Oem.Dell.ResetPowerBudget"]
+	if len(actions) != 1 ||
+		budget.Target != "/redfish/v1/Chassis/1/Power/Actions/Oem/Dell.ResetPowerBudget" ||
+		budget.ActionInfo != "/redfish/v1/Chassis/1/Power/ResetPowerBudgetActionInfo" {
+		t.Errorf("Invalid OEM actions: %+v", actions)
+	}
+
+	if err := power.PowerSupplyReset("0", OnResetType); err == nil {
+		t.Error("Expected an unsupported reset type to be rejected")
+	}
+	if err := power.PowerSupplyReset("0", ForceRestartResetType); err != nil {
+		t.Errorf("Error resetting power supply: %s", err)
+	}
+	posts := c.PostBodies("/redfish/v1/Chassis/1/Power/Actions/Power.PowerSupplyReset")
+	if len(posts) != 1 || posts[0] != `{"MemberId":"0","ResetType":"ForceRestart"}` {
+		t.Errorf("Invalid reset requests: %v", posts)
+	}
+
+	// The actions survive a round trip
+	data, err := json.Marshal(power)
+	if err != nil {
+		t.Fatalf("Error marshaling power: %s", err)
+	}
+	roundTrip, err := ParsePower(data)
+	if err != nil {
+		t.Fatalf("Error parsing power: %s", err)
+	}
+	if actions, err := roundTrip.OemActions(); err != nil || len(actions) != 1 {
+		t.Errorf("Expected the OEM actions to be kept, got %v: %v", actions, err)
+	}
+	if roundTrip.powerSupplyResetTarget != power.powerSupplyResetTarget {
+		t.Errorf("Expected the reset target to be kept, got %q", roundTrip.powerSupplyResetTarget)
+	}
+
+	// Resources without actions report none
+	plain, err := ParsePower([]byte(`{"@odata.id": "/redfish/v1/Chassis/2/Power"}`))
+	if err != nil {
+		t.Fatalf("Error parsing power: %s", err)
+	}
+	if actions, err := plain.OemActions(); err != nil || actions != nil {
+		t.Errorf("Expected no OEM actions, got %v: %v", actions, err)
+	}
+	if err := plain.PowerSupplyReset("0", ""); err == nil {
+		t.Error("Expected an unsupported action to be rejected")
+	}
+
+	// A resource without a client fails rather than panicking
+	power.Client = nil
+	if err := power.PowerSupplyReset("0", ForceRestartResetType); err == nil {
+		t.Error("Expected an error without a client")
+	}
+}
+
+// TestPowerSupplyUpdateEmbedded tests that changes to a supply embedded in a
+// Power resource are sent to the Power resource as a member of its
+// PowerSupplies array, and are verified against the member.
+func TestPowerSupplyUpdateEmbedded(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power", `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"PowerSupplies": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0", "MemberId": "0", "IndicatorLED": "Off"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1", "MemberId": "1", "IndicatorLED": "Off"}
+		]
+	}`)
+
+	power, err := GetPower(c, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	// The mock service ignores the change, which must be noticed
+	supply := &power.PowerSupplies[1]
+	supply.IndicatorLED = common.LitIndicatorLED
+	err = supply.UpdateVerified()
+	if err == nil || !strings.Contains(err.Error(), "IndicatorLED") {
+		t.Errorf("Expected the ignored change to be reported, got: %v", err)
+	}
+
+	var patched []common.MockCall
+	for _, call := range c.Calls() {
+		if call.Method == http.MethodPatch {
+			patched = append(patched, call)
+		}
+	}
+	if len(patched) != 1 || patched[0].URL != "/redfish/v1/Chassis/1/Power" {
+		t.Fatalf("Expected a single PATCH of the Power resource, got: %v", patched)
+	}
+	expected := `{"PowerSupplies":[{},{"IndicatorLED":"Lit"}]}`
+	if bodies := c.PatchBodies("/redfish/v1/Chassis/1/Power"); len(bodies) != 1 || bodies[0] != expected {
+		t.Errorf("Expected PATCH body %s, got: %v", expected, bodies)
+	}
+}
@@ -0,0 +1,248 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// PowerEquipmentType is the type of a power distribution unit.
+type PowerEquipmentType string
+
+const (
+
+	// RackPDUPowerEquipmentType A power distribution unit providing outlets
+	// for a rack or similar quantity of devices.
+	RackPDUPowerEquipmentType PowerEquipmentType = "RackPDU"
+	// FloorPDUPowerEquipmentType A power distribution unit providing feeder
+	// circuits for further power distribution.
+	FloorPDUPowerEquipmentType PowerEquipmentType = "FloorPDU"
+	// ManualTransferSwitchPowerEquipmentType A manual power transfer switch.
+	ManualTransferSwitchPowerEquipmentType PowerEquipmentType = "ManualTransferSwitch"
+	// AutomaticTransferSwitchPowerEquipmentType An automatic power transfer
+	// switch.
+	AutomaticTransferSwitchPowerEquipmentType PowerEquipmentType = "AutomaticTransferSwitch"
+	// SwitchgearPowerEquipmentType Electrical switchgear.
+	SwitchgearPowerEquipmentType PowerEquipmentType = "Switchgear"
+	// PowerShelfPowerEquipmentType A power shelf.
+	PowerShelfPowerEquipmentType PowerEquipmentType = "PowerShelf"
+	// BusPowerEquipmentType An electrical bus.
+	BusPowerEquipmentType PowerEquipmentType = "Bus"
+	// BatteryShelfPowerEquipmentType A battery shelf or battery-backed unit.
+	BatteryShelfPowerEquipmentType PowerEquipmentType = "BatteryShelf"
+)
+
+// PowerDistribution shall represent a power distribution component or unit,
+// such as a rack PDU, for a Redfish implementation. These are found under
+// /redfish/v1/PowerEquipment rather than under a chassis.
+type PowerDistribution struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag shall contain the user-assigned asset tag, which is an
+	// identifying string that tracks the equipment for inventory purposes.
+	AssetTag string
+	// Description provides a description of this resource.
+	Description string
+	// EquipmentType shall contain the type of equipment this resource
+	// represents.
+	EquipmentType PowerEquipmentType
+	// FirmwareVersion shall contain a string describing the firmware version
+	// of this equipment as provided by the manufacturer.
+	FirmwareVersion string
+	// Location shall contain the location information of the power
+	// distribution equipment.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the equipment.
+	Manufacturer string
+	// Model shall contain the manufacturer-provided model information of
+	// this equipment.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// equipment.
+	PartNumber string
+	// PowerCapacityVA shall contain the maximum power capacity, in
+	// volt-amperes, of this equipment.
+	PowerCapacityVA float64
+	// ProductionDate shall contain the date of production or manufacture for
+	// this equipment.
+	ProductionDate string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the equipment.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UUID shall contain the universally unique identifier number for the
+	// equipment.
+	UUID string
+	// Version shall contain the hardware version of this equipment as
+	// determined by the vendor or supplier.
+	Version string
+	// branches is the link to the collection of branch circuits.
+	branches string
+	// mains is the link to the collection of mains circuits.
+	mains string
+	// metrics is the link to the PowerDistributionMetrics resource.
+	metrics string
+	// outlets is the link to the collection of outlets.
+	outlets string
+	// sensors is the link to the collection of sensors.
+	sensors string
+}
+
+// UnmarshalJSON unmarshals a PowerDistribution object from the raw JSON.
+func (powerdistribution *PowerDistribution) UnmarshalJSON(b []byte) error {
+	type temp PowerDistribution
+	var t struct {
+		temp
+		Branches common.Link
+		Mains    common.Link
+		Metrics  common.Link
+		Outlets  common.Link
+		Sensors  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerdistribution = PowerDistribution(t.temp)
+
+	// Extract the links to other entities for later
+	powerdistribution.branches = string(t.Branches)
+	powerdistribution.mains = string(t.Mains)
+	powerdistribution.metrics = string(t.Metrics)
+	powerdistribution.outlets = string(t.Outlets)
+	powerdistribution.sensors = string(t.Sensors)
+
+	return nil
+}
+
+// GetPowerDistribution will get a PowerDistribution instance from the
+// service.
+func GetPowerDistribution(c common.Client, uri string) (*PowerDistribution, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var powerdistribution PowerDistribution
+	err = json.NewDecoder(resp.Body).Decode(&powerdistribution)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	powerdistribution.SetClient(c)
+	return &powerdistribution, nil
+}
+
+// ListReferencedPowerDistributions gets the collection of PowerDistribution
+// from a provided reference, such as
+// /redfish/v1/PowerEquipment/RackPDUs.
+func ListReferencedPowerDistributions(c common.Client, link string) ([]*PowerDistribution, error) { //nolint:dupl
+	var result []*PowerDistribution
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, powerdistributionLink := range links.ItemLinks {
+		powerdistribution, err := GetPowerDistribution(c, powerdistributionLink)
+		if err != nil {
+			collectionError.Failures[powerdistributionLink] = err
+		} else {
+			result = append(result, powerdistribution)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Branches gets the branch circuits of the equipment from its Branches
+// collection.
+func (powerdistribution *PowerDistribution) Branches() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.branches)
+}
+
+// Mains gets the mains input circuits of the equipment from its Mains
+// collection.
+func (powerdistribution *PowerDistribution) Mains() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.mains)
+}
+
+// Metrics gets the summary metrics of the equipment, or nil if the service
+// does not link to them.
+func (powerdistribution *PowerDistribution) Metrics() (*PowerDistributionMetrics, error) {
+	if powerdistribution.metrics == "" {
+		return nil, nil
+	}
+
+	return GetPowerDistributionMetrics(powerdistribution.Client, powerdistribution.metrics)
+}
+
+// Outlets gets the outlets of the equipment from its Outlets collection.
+func (powerdistribution *PowerDistribution) Outlets() ([]*Outlet, error) {
+	return ListReferencedOutlets(powerdistribution.Client, powerdistribution.outlets)
+}
+
+// Sensors gets the sensors of the equipment from its Sensors collection.
+func (powerdistribution *PowerDistribution) Sensors() ([]*Sensor, error) {
+	return ListReferencedSensors(powerdistribution.Client, powerdistribution.sensors)
+}
+
+// PowerDistributionMetrics shall contain the summary metrics of a power
+// distribution component or unit.
+type PowerDistributionMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hours, that
+	// the equipment has delivered.
+	EnergykWh EnergyReading
+	// PowerWatts shall contain the total power, in watts, that the equipment
+	// is currently delivering.
+	PowerWatts PowerReading
+}
+
+// GetPowerDistributionMetrics will get a PowerDistributionMetrics instance
+// from the service.
+func GetPowerDistributionMetrics(c common.Client, uri string) (*PowerDistributionMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var metrics PowerDistributionMetrics
+	err = json.NewDecoder(resp.Body).Decode(&metrics)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	metrics.SetClient(c)
+	return &metrics, nil
+}
@@ -0,0 +1,101 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+var powerDistributionBody = `{
+	"@odata.type": "#PowerDistribution.v1_2_1.PowerDistribution",
+	"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1",
+	"Id": "1",
+	"Name": "RackPDU1",
+	"EquipmentType": "RackPDU",
+	"FirmwareVersion": "4.3.0",
+	"Version": "1.03b",
+	"ProductionDate": "2017-01-11T08:00:00Z",
+	"Manufacturer": "Contoso",
+	"Model": "ZAP4000",
+	"SerialNumber": "29347ZT536",
+	"PartNumber": "AA-23",
+	"UUID": "32354641-4135-4332-4a35-313735303734",
+	"AssetTag": "PDX-92381",
+	"PowerCapacityVA": 17280,
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"Location": {"Placement": {"Row": "North 1"}},
+	"Mains": {"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains"},
+	"Branches": {"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Branches"},
+	"Outlets": {"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets"},
+	"Metrics": {"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Metrics"},
+	"Sensors": {"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors"}
+}`
+
+// TestPowerDistribution tests the parsing of PowerDistribution objects.
+func TestPowerDistribution(t *testing.T) {
+	var result PowerDistribution
+	err := json.NewDecoder(strings.NewReader(powerDistributionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.EquipmentType != RackPDUPowerEquipmentType {
+		t.Errorf("Invalid EquipmentType: %s", result.EquipmentType)
+	}
+
+	if result.PowerCapacityVA != 17280 {
+		t.Errorf("Invalid PowerCapacityVA: %g", result.PowerCapacityVA)
+	}
+
+	if result.outlets != "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets" {
+		t.Errorf("Invalid outlets link: %s", result.outlets)
+	}
+
+	if result.branches != "/redfish/v1/PowerEquipment/RackPDUs/1/Branches" ||
+		result.mains != "/redfish/v1/PowerEquipment/RackPDUs/1/Mains" {
+		t.Errorf("Invalid circuit links: %s, %s", result.branches, result.mains)
+	}
+}
+
+// TestListReferencedPowerDistributions tests reading PDUs and their metrics.
+func TestListReferencedPowerDistributions(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs", `{
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1"}]
+	}`)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1", powerDistributionBody)
+	c.SetBody("/redfish/v1/PowerEquipment/RackPDUs/1/Metrics", `{
+		"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Metrics",
+		"Id": "Metrics",
+		"PowerWatts": {"Reading": 6438, "ApparentVA": 6300, "PowerFactor": 0.93},
+		"EnergykWh": {"Reading": 36166}
+	}`)
+
+	pdus, err := ListReferencedPowerDistributions(c, "/redfish/v1/PowerEquipment/RackPDUs")
+	if err != nil {
+		t.Fatalf("Error listing PDUs: %s", err)
+	}
+	if len(pdus) != 1 || pdus[0].SerialNumber != "29347ZT536" {
+		t.Fatalf("Invalid PDUs: %v", pdus)
+	}
+
+	metrics, err := pdus[0].Metrics()
+	if err != nil {
+		t.Fatalf("Error getting metrics: %s", err)
+	}
+	if metrics.PowerWatts.Reading != 6438 || metrics.EnergykWh.Reading != 36166 {
+		t.Errorf("Invalid metrics: %+v", metrics)
+	}
+}
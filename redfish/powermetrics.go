@@ -0,0 +1,93 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// EnergyReading shall describe an energy sensor reading.
+type EnergyReading struct {
+	// DataSourceURI shall contain a URI to the Sensor resource providing
+	// this reading.
+	DataSourceURI string `json:"DataSourceUri"`
+	// LifetimeReading shall contain the total accumulation of energy, in
+	// kilowatt-hours, since the device was manufactured.
+	LifetimeReading float64
+	// Reading shall contain the energy, in kilowatt-hours, accumulated since
+	// the sensor was last reset.
+	Reading float64
+	// SensorResetTime shall contain the date and time when the reading was
+	// last reset.
+	SensorResetTime string
+}
+
+// PowerReading shall describe a power sensor reading.
+type PowerReading struct {
+	// ApparentVA shall contain the product of voltage and current for an AC
+	// circuit, in volt-amperes.
+	ApparentVA float64
+	// DataSourceURI shall contain a URI to the Sensor resource providing
+	// this reading.
+	DataSourceURI string `json:"DataSourceUri"`
+	// PowerFactor shall identify the quotient of real power and apparent
+	// power for an AC circuit.
+	PowerFactor float64
+	// ReactiveVAR shall contain the arithmetic mean of the product of voltage
+	// and current, with the phase of one shifted by 90 degrees, in
+	// volt-amperes reactive.
+	ReactiveVAR float64
+	// Reading shall contain the power reading in watts.
+	Reading float64
+}
+
+// PowerMetrics shall contain the aggregated power metrics of a chassis. It
+// supersedes the PowerMetrics object embedded in PowerControl.
+type PowerMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AverageConsumedWatts shall represent the average power level that
+	// occurred over the last IntervalInMin minutes.
+	AverageConsumedWatts float64
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the energy, in kilowatt-hours, consumed.
+	EnergykWh EnergyReading
+	// IntervalInMin shall represent the time interval, in minutes, over
+	// which the consumption metrics are measured.
+	IntervalInMin float64
+	// MaxConsumedWatts shall represent the maximum power level that occurred
+	// within the last IntervalInMin minutes.
+	MaxConsumedWatts float64
+	// MinConsumedWatts shall represent the minimum power level that occurred
+	// within the last IntervalInMin minutes.
+	MinConsumedWatts float64
+	// PowerWatts shall contain the total power, in watts, currently consumed.
+	PowerWatts PowerReading
+}
+
+// GetPowerMetrics will get a PowerMetrics instance from the service.
+func GetPowerMetrics(c common.Client, uri string) (*PowerMetrics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var powermetrics PowerMetrics
+	err = json.NewDecoder(resp.Body).Decode(&powermetrics)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	powermetrics.SetClient(c)
+	return &powermetrics, nil
+}
@@ -0,0 +1,107 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+var powerMetricsBody = `{
+		"@odata.type": "#PowerMetrics.v1_0_0.PowerMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1/Power/PowerMetrics",
+		"Id": "PowerMetrics",
+		"Name": "Chassis Power Metrics",
+		"AverageConsumedWatts": 410.5,
+		"IntervalInMin": 30,
+		"MaxConsumedWatts": 520,
+		"MinConsumedWatts": 300,
+		"EnergykWh": {
+			"Reading": 1250.4,
+			"LifetimeReading": 9800,
+			"SensorResetTime": "2021-01-01T00:00:00Z"
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/TotalPower",
+			"Reading": 415,
+			"ApparentVA": 430,
+			"PowerFactor": 0.97
+		}
+	}`
+
+// TestPowerFetchMetrics tests following the PowerMetrics link of a Power
+// resource.
+func TestPowerFetchMetrics(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				getCall(`{"@odata.id": "/redfish/v1/Chassis/1/Power", "@odata.type": "#Power.v1_7_0.Power",
+					"PowerMetrics": {"@odata.id": "/redfish/v1/Chassis/1/Power/PowerMetrics"}}`),
+				getCall(powerMetricsBody),
+			},
+		},
+	}
+
+	power, err := GetPower(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	metrics, err := power.FetchMetrics(testClient)
+	if err != nil {
+		t.Fatalf("Error fetching metrics: %s", err)
+	}
+	if metrics == nil {
+		t.Fatal("Expected metrics to be returned")
+	}
+
+	if metrics.AverageConsumedWatts != 410.5 || metrics.IntervalInMin != 30 {
+		t.Errorf("Invalid consumption metrics: %+v", metrics)
+	}
+	if metrics.EnergykWh.Reading != 1250.4 || metrics.EnergykWh.LifetimeReading != 9800 {
+		t.Errorf("Invalid energy reading: %+v", metrics.EnergykWh)
+	}
+	if metrics.PowerWatts.Reading != 415 || metrics.PowerWatts.PowerFactor != 0.97 {
+		t.Errorf("Invalid power reading: %+v", metrics.PowerWatts)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[1].URL != "/redfish/v1/Chassis/1/Power/PowerMetrics" {
+		t.Errorf("Unexpected metrics URL: %s", calls[1].URL)
+	}
+}
+
+// TestPowerFetchMetricsNoLink tests a Power resource without a metrics link.
+func TestPowerFetchMetricsNoLink(t *testing.T) {
+	testClient := &common.TestClient{}
+
+	metrics, err := (&Power{}).FetchMetrics(testClient)
+	if err != nil || metrics != nil {
+		t.Errorf("Expected no metrics, got %v, %v", metrics, err)
+	}
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestGetPowerMetricsErrors tests that failures are classified.
+func TestGetPowerMetricsErrors(t *testing.T) {
+	c := common.NewMockClient()
+	c.SetBody("/redfish/v1/Chassis/1/Power/PowerMetrics", `{"AverageConsumedWatts": "lots"}`)
+
+	var redfishErr *common.RedfishError
+	if _, err := GetPowerMetrics(c, "/redfish/v1/Chassis/2/Power/PowerMetrics"); !errors.As(err, &redfishErr) {
+		t.Errorf("Expected a RedfishError for the missing resource, got: %v", err)
+	}
+
+	var parseErr *common.ParseError
+	_, err := GetPowerMetrics(c, "/redfish/v1/Chassis/1/Power/PowerMetrics")
+	if !errors.As(err, &parseErr) || parseErr.URI != "/redfish/v1/Chassis/1/Power/PowerMetrics" {
+		t.Errorf("Expected a ParseError, got: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// defaultPollerConcurrency is the number of Power resources a PowerPoller
+// fetches at the same time if no concurrency is configured.
+const defaultPollerConcurrency = 16
+
+// defaultPollerInterval is the time between polling cycles of a PowerPoller
+// if no valid interval is configured.
+const defaultPollerInterval = time.Minute
+
+// PowerSnapshot is a parsed Power resource fetched by a PowerPoller.
+type PowerSnapshot struct {
+	// URI is the URI the Power resource was fetched from.
+	URI string
+	// Power is the parsed resource.
+	Power *Power
+	// Time is when the fetch completed.
+	Time time.Time
+}
+
+// PowerPoller periodically fetches a set of Power resources. Each request in
+// a polling cycle is delayed by a random amount of up to Jitter, so that
+// pollers started at the same time do not keep hitting services in lockstep.
+type PowerPoller struct {
+	// Client is the client used to fetch the Power resources.
+	Client common.Client
+	// URIs are the Power resources to poll.
+	URIs []string
+	// Interval is the time between the start of two polling cycles. If not
+	// positive, defaultPollerInterval is used.
+	Interval time.Duration
+	// Jitter is the maximum random delay of each request within a cycle.
+	Jitter time.Duration
+	// Concurrency is the maximum number of requests in flight. If zero,
+	// defaultPollerConcurrency is used.
+	Concurrency int
+
+	mu         sync.Mutex
+	lastErrors map[string]error
+}
+
+// NewPowerPoller creates a PowerPoller for the given URIs with no jitter and
+// the default concurrency.
+func NewPowerPoller(c common.Client, uris []string, interval time.Duration) *PowerPoller {
+	return &PowerPoller{
+		Client:   c,
+		URIs:     uris,
+		Interval: interval,
+	}
+}
+
+// Run starts polling and returns the channel snapshots are emitted on. A
+// polling cycle starts immediately and then every Interval; a cycle that
+// overruns the interval delays the next one. Polling stops, requests in
+// flight are abandoned and the channel is closed when ctx is cancelled.
+func (poller *PowerPoller) Run(ctx context.Context) <-chan PowerSnapshot {
+	snapshots := make(chan PowerSnapshot)
+
+	go func() {
+		defer close(snapshots)
+
+		interval := poller.Interval
+		if interval <= 0 {
+			interval = defaultPollerInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			poller.poll(ctx, snapshots)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return snapshots
+}
+
+// poll runs a single polling cycle and waits for it to complete.
+func (poller *PowerPoller) poll(ctx context.Context, snapshots chan<- PowerSnapshot) {
+	concurrency := poller.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPollerConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for _, uri := range poller.URIs {
+		wg.Add(1)
+		go func(uri string) {
+			defer wg.Done()
+
+			if poller.Jitter > 0 {
+				timer := time.NewTimer(time.Duration(rand.Int63n(int64(poller.Jitter)))) // nolint:gosec
+				defer timer.Stop()
+				select {
+				case <-ctx.Done():
+					return
+				case <-timer.C:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+			power, err := GetPowerWithContext(ctx, poller.Client, uri)
+			<-sem
+
+			// A fetch abandoned because polling stopped says nothing about
+			// the resource
+			if ctx.Err() != nil {
+				return
+			}
+			poller.setLastError(uri, err)
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+			case snapshots <- PowerSnapshot{URI: uri, Power: power, Time: time.Now()}:
+			}
+		}(uri)
+	}
+	wg.Wait()
+}
+
+// setLastError records the outcome of the latest fetch of a URI.
+func (poller *PowerPoller) setLastError(uri string, err error) {
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+
+	if poller.lastErrors == nil {
+		poller.lastErrors = make(map[string]error)
+	}
+	if err == nil {
+		delete(poller.lastErrors, uri)
+		return
+	}
+	poller.lastErrors[uri] = err
+}
+
+// LastError returns the error of the latest fetch of a URI, or nil if it
+// succeeded or has not been attempted yet.
+func (poller *PowerPoller) LastError(uri string) error {
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+
+	return poller.lastErrors[uri]
+}
+
+// LastErrors returns the URIs whose latest fetch failed, with their errors.
+func (poller *PowerPoller) LastErrors() map[string]error {
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+
+	result := make(map[string]error, len(poller.lastErrors))
+	for uri, err := range poller.lastErrors {
+		result[uri] = err
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// TestPowerPoller tests polling Power resources and tracking failures.
+func TestPowerPoller(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Power": `{
+			"@odata.type": "#Power.v1_5_3.Power",
+			"@odata.id": "/redfish/v1/Chassis/1/Power",
+			"Id": "Power",
+			"Name": "Power"
+		}`,
+	}}
+
+	poller := NewPowerPoller(testClient, []string{
+		"/redfish/v1/Chassis/1/Power",
+		"/redfish/v1/Chassis/2/Power",
+	}, 10*time.Millisecond)
+	poller.Jitter = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshots := poller.Run(ctx)
+	for i := 0; i < 2; i++ {
+		snapshot, ok := <-snapshots
+		if !ok {
+			t.Fatal("Snapshot channel closed early")
+		}
+		if snapshot.URI != "/redfish/v1/Chassis/1/Power" || snapshot.Power.ID != "Power" {
+			t.Errorf("Invalid snapshot: %+v", snapshot)
+		}
+	}
+	cancel()
+	for range snapshots {
+	}
+
+	var redfishErr *common.RedfishError
+	if err := poller.LastError("/redfish/v1/Chassis/2/Power"); !errors.As(err, &redfishErr) {
+		t.Errorf("Expected a RedfishError for the missing resource, got: %v", err)
+	}
+	if err := poller.LastError("/redfish/v1/Chassis/1/Power"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if errs := poller.LastErrors(); len(errs) != 1 {
+		t.Errorf("Expected one failing URI, got: %v", errs)
+	}
+}
+
+// TestPowerPollerZeroInterval tests that a poller without an interval uses
+// the default rather than failing.
+func TestPowerPollerZeroInterval(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1/Power": `{"@odata.id": "/redfish/v1/Chassis/1/Power", "Id": "Power"}`,
+	}}
+	poller := &PowerPoller{Client: testClient, URIs: []string{"/redfish/v1/Chassis/1/Power"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshots := poller.Run(ctx)
+	if snapshot, ok := <-snapshots; !ok || snapshot.Power.ID != "Power" {
+		t.Errorf("Invalid snapshot: %+v", snapshot)
+	}
+	cancel()
+	for range snapshots {
+	}
+}
@@ -0,0 +1,129 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// defaultSamplerCapacity is the number of samples a PowerSampler keeps if no
+// capacity is given.
+const defaultSamplerCapacity = 1024
+
+// powerSample is a consumption reading taken at a point in time.
+type powerSample struct {
+	time  time.Time
+	watts float64
+}
+
+// PowerSampler accumulates PowerConsumedWatts readings, such as from the
+// snapshots of a PowerPoller, to compute the minimum, maximum and average
+// consumption over a window of its own, in addition to the PowerMetrics the
+// service computes over its interval. Samples older than the window are
+// dropped as new ones are added, and at most a fixed number of samples are
+// kept, dropping the oldest first. It is safe for concurrent use.
+type PowerSampler struct {
+	mu      sync.Mutex
+	window  time.Duration
+	samples []powerSample
+	// start is the position of the oldest of the count samples in the
+	// samples ring.
+	start int
+	count int
+}
+
+// NewPowerSampler creates a PowerSampler computing statistics over the given
+// window and keeping up to capacity samples. A capacity below one means
+// defaultSamplerCapacity.
+func NewPowerSampler(window time.Duration, capacity int) *PowerSampler {
+	if capacity <= 0 {
+		capacity = defaultSamplerCapacity
+	}
+
+	return &PowerSampler{
+		window:  window,
+		samples: make([]powerSample, capacity),
+	}
+}
+
+// Add records a consumption reading taken at t. Samples are expected to be
+// added in time order.
+func (sampler *PowerSampler) Add(t time.Time, watts float64) {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+
+	// Drop the samples that have left the window
+	for sampler.count > 0 && t.Sub(sampler.samples[sampler.start].time) > sampler.window {
+		sampler.start = (sampler.start + 1) % len(sampler.samples)
+		sampler.count--
+	}
+
+	// Overwrite the oldest sample once full
+	if sampler.count == len(sampler.samples) {
+		sampler.start = (sampler.start + 1) % len(sampler.samples)
+		sampler.count--
+	}
+
+	sampler.samples[(sampler.start+sampler.count)%len(sampler.samples)] = powerSample{time: t, watts: watts}
+	sampler.count++
+}
+
+// AddSnapshot records the consumption of the chassis in a snapshot, taken
+// from its first PowerControl. False is returned, and nothing recorded, if
+// the snapshot does not report PowerConsumedWatts.
+func (sampler *PowerSampler) AddSnapshot(snapshot PowerSnapshot) bool {
+	consumed := snapshot.Power.chassisControl().ConsumedWatts()
+	if !consumed.Present {
+		return false
+	}
+
+	sampler.Add(snapshot.Time, consumed.Value)
+	return true
+}
+
+// Len returns the number of samples kept.
+func (sampler *PowerSampler) Len() int {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+	return sampler.count
+}
+
+// Metric returns the minimum, maximum and average of the samples taken in
+// the window ending at now, with IntervalInMin set to the window. The
+// average is the plain mean of the samples, so readings should be taken at
+// a regular interval. False is returned if there are no samples in the
+// window.
+func (sampler *PowerSampler) Metric(now time.Time) (PowerMetric, bool) {
+	sampler.mu.Lock()
+	defer sampler.mu.Unlock()
+
+	result := PowerMetric{
+		IntervalInMin:    sampler.window.Minutes(),
+		MinConsumedWatts: math.Inf(1),
+		MaxConsumedWatts: math.Inf(-1),
+	}
+
+	var total float64
+	var n int
+	for i := 0; i < sampler.count; i++ {
+		sample := sampler.samples[(sampler.start+i)%len(sampler.samples)]
+		if sample.time.After(now) || now.Sub(sample.time) > sampler.window {
+			continue
+		}
+		result.MinConsumedWatts = math.Min(result.MinConsumedWatts, sample.watts)
+		result.MaxConsumedWatts = math.Max(result.MaxConsumedWatts, sample.watts)
+		total += sample.watts
+		n++
+	}
+
+	if n == 0 {
+		return PowerMetric{IntervalInMin: result.IntervalInMin}, false
+	}
+
+	result.AverageConsumedWatts = total / float64(n)
+	return result, true
+}
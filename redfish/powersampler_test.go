@@ -0,0 +1,85 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPowerSampler tests computing statistics over a window of samples.
+func TestPowerSampler(t *testing.T) {
+	start := time.Unix(1000, 0)
+	sampler := NewPowerSampler(time.Minute, 4)
+
+	if _, ok := sampler.Metric(start); ok {
+		t.Error("Expected no metric without samples")
+	}
+
+	for i, watts := range []float64{300, 340, 320, 360} {
+		sampler.Add(start.Add(time.Duration(i)*10*time.Second), watts)
+	}
+
+	metric, ok := sampler.Metric(start.Add(30 * time.Second))
+	if !ok || metric.MinConsumedWatts != 300 || metric.MaxConsumedWatts != 360 ||
+		metric.AverageConsumedWatts != 330 || metric.IntervalInMin != 1 {
+		t.Errorf("Invalid metric: %+v", metric)
+	}
+
+	// The oldest sample is dropped once the sampler is full
+	sampler.Add(start.Add(40*time.Second), 380)
+	if sampler.Len() != 4 {
+		t.Errorf("Expected 4 samples, have %d", sampler.Len())
+	}
+	metric, _ = sampler.Metric(start.Add(40 * time.Second))
+	if metric.MinConsumedWatts != 320 || metric.MaxConsumedWatts != 380 || metric.AverageConsumedWatts != 350 {
+		t.Errorf("Invalid metric after overwriting: %+v", metric)
+	}
+
+	// Samples leave the window as time passes
+	metric, _ = sampler.Metric(start.Add(85 * time.Second))
+	if metric.MinConsumedWatts != 360 || metric.AverageConsumedWatts != 370 {
+		t.Errorf("Invalid metric for the later window: %+v", metric)
+	}
+	sampler.Add(start.Add(5*time.Minute), 200)
+	if sampler.Len() != 1 {
+		t.Errorf("Expected the old samples to be dropped, have %d", sampler.Len())
+	}
+}
+
+// TestPowerSamplerSnapshots tests sampling PowerPoller snapshots concurrently.
+func TestPowerSamplerSnapshots(t *testing.T) {
+	var power Power
+	if err := json.Unmarshal([]byte(`{"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": 344}]}`), &power); err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	now := time.Unix(1000, 0)
+	sampler := NewPowerSampler(time.Hour, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sampler.AddSnapshot(PowerSnapshot{Power: &power, Time: now})
+				sampler.Metric(now)
+			}
+		}()
+	}
+	wg.Wait()
+
+	metric, ok := sampler.Metric(now)
+	if !ok || sampler.Len() != 800 || metric.AverageConsumedWatts != 344 {
+		t.Errorf("Invalid metric over %d samples: %+v", sampler.Len(), metric)
+	}
+
+	if sampler.AddSnapshot(PowerSnapshot{Power: &Power{}, Time: now}) {
+		t.Error("Expected a snapshot without consumption to be skipped")
+	}
+}
@@ -0,0 +1,315 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// RedundancyType is the redundancy type of a group of power supplies.
+type RedundancyType string
+
+const (
+
+	// FailoverRedundancyType Failure of one unit will automatically cause
+	// its functions to be taken over by a standby or offline unit in the
+	// redundancy set.
+	FailoverRedundancyType RedundancyType = "Failover"
+	// NPlusMRedundancyType Multiple units are available and active such that
+	// normal operation will continue if one or more units fail.
+	NPlusMRedundancyType RedundancyType = "NPlusM"
+	// SharingRedundancyType Multiple units contribute or share such that
+	// operation will continue, but at a reduced capacity, if one or more
+	// units fail.
+	SharingRedundancyType RedundancyType = "Sharing"
+	// SparingRedundancyType One or more spare units are available to take
+	// over the function of a failed unit, but takeover is not automatic.
+	SparingRedundancyType RedundancyType = "Sparing"
+	// NotRedundantRedundancyType The subsystem is not configured in a
+	// redundancy mode, either due to configuration or the functionality has
+	// been disabled by the user.
+	NotRedundantRedundancyType RedundancyType = "NotRedundant"
+)
+
+// PowerAllocation shall contain the allocation of power for a subsystem.
+type PowerAllocation struct {
+	// AllocatedWatts shall contain the total amount of power, in watts,
+	// currently allocated to the subsystem.
+	AllocatedWatts float64
+	// RequestedWatts shall contain the amount of power, in watts, that the
+	// subsystem currently requests to be budgeted for future use.
+	RequestedWatts float64
+}
+
+// RedundantGroup shall contain redundancy information for a set of devices.
+type RedundantGroup struct {
+	// MaxSupportedInGroup shall contain the maximum number of devices allowed
+	// in the redundancy group.
+	MaxSupportedInGroup int
+	// MinNeededInGroup shall contain the minimum number of functional devices
+	// needed in the redundancy group for the current redundancy mode to be
+	// fault tolerant.
+	MinNeededInGroup int
+	// RedundancyType shall contain the redundancy type of the group.
+	RedundancyType RedundancyType
+	// Status shall contain any status or health properties of the group.
+	Status common.Status
+}
+
+// PowerSubsystem shall describe the power subsystem of a chassis. It replaces
+// the deprecated Power resource in newer Redfish implementations.
+type PowerSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Allocation shall contain the set of properties describing the
+	// allocation of power for this subsystem.
+	Allocation PowerAllocation
+	// CapacityWatts shall contain the total power capacity that can be
+	// allocated to this subsystem.
+	CapacityWatts float64
+	// Description provides a description of this resource.
+	Description string
+	// PowerSupplyRedundancy shall contain redundancy information for the set
+	// of power supplies of this subsystem.
+	PowerSupplyRedundancy []RedundantGroup
+	// Status shall contain any status or health properties
+	// of the resource.
+	Status common.Status
+	// supplies holds the power supplies carried over when converting from a
+	// legacy Power resource.
+	supplies []PowerSupply
+	// powerSupplies is the link to the collection of power supplies.
+	powerSupplies string
+	// batteries is the link to the collection of batteries.
+	batteries string
+}
+
+// UnmarshalJSON unmarshals a PowerSubsystem object from the raw JSON.
+func (powersubsystem *PowerSubsystem) UnmarshalJSON(b []byte) error {
+	type temp PowerSubsystem
+	var t struct {
+		temp
+		Batteries     common.Link
+		PowerSupplies common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersubsystem = PowerSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	powersubsystem.batteries = string(t.Batteries)
+	powersubsystem.powerSupplies = string(t.PowerSupplies)
+
+	return nil
+}
+
+// GetPowerSubsystem will get a PowerSubsystem instance from the service.
+func GetPowerSubsystem(c common.Client, uri string) (*PowerSubsystem, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var powersubsystem PowerSubsystem
+	err = json.NewDecoder(resp.Body).Decode(&powersubsystem)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	powersubsystem.SetClient(c)
+	return &powersubsystem, nil
+}
+
+// PowerSupplies gets the power supplies of this subsystem from its
+// PowerSupplies collection. A subsystem converted from a legacy Power
+// resource returns the power supplies it was converted with instead.
+func (powersubsystem *PowerSubsystem) PowerSupplies() ([]*PowerSupply, error) {
+	if powersubsystem.powerSupplies == "" {
+		result := make([]*PowerSupply, 0, len(powersubsystem.supplies))
+		for i := range powersubsystem.supplies {
+			result = append(result, &powersubsystem.supplies[i])
+		}
+
+		return result, nil
+	}
+
+	return ListReferencedPowerSupplies(powersubsystem.Client, powersubsystem.powerSupplies)
+}
+
+// Batteries gets the batteries of this subsystem from its Batteries
+// collection.
+func (powersubsystem *PowerSubsystem) Batteries() ([]*Battery, error) {
+	return ListReferencedBatteries(powersubsystem.Client, powersubsystem.batteries)
+}
+
+// GetPowerSupply will get a PowerSupply instance from the service, as listed
+// in the PowerSupplies collection of a PowerSubsystem.
+func GetPowerSupply(c common.Client, uri string) (*PowerSupply, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var powersupply PowerSupply
+	err = json.NewDecoder(resp.Body).Decode(&powersupply)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	powersupply.SetClient(c)
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		powersupply.etag = etag
+	}
+	return &powersupply, nil
+}
+
+// ListReferencedPowerSupplies gets the collection of PowerSupply from
+// a provided reference.
+func ListReferencedPowerSupplies(c common.Client, link string) ([]*PowerSupply, error) { //nolint:dupl
+	var result []*PowerSupply
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, powersupplyLink := range links.ItemLinks {
+		powersupply, err := GetPowerSupply(c, powersupplyLink)
+		if err != nil {
+			collectionError.Failures[powersupplyLink] = err
+		} else {
+			result = append(result, powersupply)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// legacyRedundancyTypes maps Power redundancy modes to PowerSubsystem
+// redundancy types, which differ only in the N+m spelling.
+var legacyRedundancyTypes = map[RedundancyMode]RedundancyType{
+	FailoverRedundancyMode:     FailoverRedundancyType,
+	NMRedundancyMode:           NPlusMRedundancyType,
+	SharingRedundancyMode:      SharingRedundancyType,
+	SparingRedundancyMode:      SparingRedundancyType,
+	NotRedundantRedundancyMode: NotRedundantRedundancyType,
+}
+
+// ToPowerSubsystem converts a legacy Power resource to the PowerSubsystem
+// model so callers can handle both schemas the same way. The capacity and
+// allocation are taken from the first PowerControl, which by convention
+// describes the whole chassis, and the power supplies and redundancy groups
+// are carried over.
+//
+// PowerSubsystem has no equivalent for, and so drops: the Voltages, the
+// consumption readings, PowerMetrics and PowerLimit of each PowerControl
+// (these moved to the EnvironmentMetrics and Control resources), any
+// PowerControl after the first, and the redundancy set membership links.
+func (power *Power) ToPowerSubsystem() *PowerSubsystem {
+	result := &PowerSubsystem{
+		Entity:      power.Entity,
+		Description: power.Description,
+	}
+
+	if len(power.PowerControl) > 0 {
+		control := &power.PowerControl[0]
+		result.CapacityWatts = control.PowerCapacityWatts
+		result.Allocation = PowerAllocation{
+			AllocatedWatts: control.PowerAllocatedWatts,
+			RequestedWatts: control.PowerRequestedWatts,
+		}
+		result.Status = control.Status
+	}
+
+	for i := range power.Redundancy {
+		redundancy := &power.Redundancy[i]
+		result.PowerSupplyRedundancy = append(result.PowerSupplyRedundancy, RedundantGroup{
+			MaxSupportedInGroup: redundancy.MaxNumSupported,
+			MinNeededInGroup:    redundancy.MinNumNeeded,
+			RedundancyType:      legacyRedundancyTypes[redundancy.Mode],
+			Status:              redundancy.Status,
+		})
+	}
+
+	result.supplies = append(result.supplies, power.PowerSupplies...)
+
+	return result
+}
+
+// ToPower converts a PowerSubsystem to the legacy Power model. The capacity,
+// allocation and status are represented by a single PowerControl, and any
+// power supplies held by the subsystem and its redundancy groups are carried
+// over. Power supplies are not fetched from the service; only those already
+// held, such as from a previous ToPowerSubsystem conversion, are included.
+// Redundancy groups whose type has no legacy mode are kept with an empty
+// Mode.
+//
+// Power has no equivalent for, and so drops, the redundancy group status
+// rollups. The resulting PowerControl has no consumption readings,
+// PowerMetrics or PowerLimit since PowerSubsystem does not report them.
+func (powersubsystem *PowerSubsystem) ToPower() *Power {
+	result := &Power{
+		Entity:      powersubsystem.Entity,
+		Description: powersubsystem.Description,
+		PowerControl: []PowerControl{
+			{
+				MemberID:            "0",
+				PhysicalContext:     common.PowerSubsystemPhysicalContext,
+				PowerAllocatedWatts: powersubsystem.Allocation.AllocatedWatts,
+				PowerAvailableWatts: powersubsystem.CapacityWatts - powersubsystem.Allocation.AllocatedWatts,
+				PowerCapacityWatts:  powersubsystem.CapacityWatts,
+				PowerRequestedWatts: powersubsystem.Allocation.RequestedWatts,
+				Status:              powersubsystem.Status,
+				capacityReported:    true,
+			},
+		},
+	}
+	result.PowerControlCount = len(result.PowerControl)
+
+	for i, group := range powersubsystem.PowerSupplyRedundancy {
+		// A group whose type has no legacy mode is kept with an empty Mode
+		var mode RedundancyMode
+		for legacy, current := range legacyRedundancyTypes {
+			if current == group.RedundancyType {
+				mode = legacy
+				break
+			}
+		}
+		result.Redundancy = append(result.Redundancy, Redundancy{
+			MaxNumSupported: group.MaxSupportedInGroup,
+			MemberID:        strconv.Itoa(i),
+			MinNumNeeded:    group.MinNeededInGroup,
+			Mode:            mode,
+			Status:          group.Status,
+		})
+	}
+	result.RedundancyCount = len(result.Redundancy)
+
+	result.PowerSupplies = append(result.PowerSupplies, powersubsystem.supplies...)
+	result.PowerSuppliesCount = len(result.PowerSupplies)
+
+	return result
+}
@@ -0,0 +1,242 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// TestPowerToPowerSubsystem tests converting between the Power and
+// PowerSubsystem models.
+func TestPowerToPowerSubsystem(t *testing.T) {
+	var power Power
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerControl": [{
+			"MemberId": "0",
+			"PowerAllocatedWatts": 600,
+			"PowerCapacityWatts": 1000,
+			"PowerConsumedWatts": 450,
+			"PowerRequestedWatts": 700,
+			"PowerLimit": {"LimitInWatts": 800}
+		}],
+		"PowerSupplies": [
+			{"MemberId": "0", "Name": "PSU1", "PowerCapacityWatts": 500},
+			{"MemberId": "1", "Name": "PSU2", "PowerCapacityWatts": 500}
+		],
+		"Redundancy": [{
+			"MemberId": "0",
+			"Mode": "N+m",
+			"MaxNumSupported": 2,
+			"MinNumNeeded": 1
+		}],
+		"Voltages": [{"MemberId": "0", "ReadingVolts": 12}]
+	}`)).Decode(&power)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	subsystem := power.ToPowerSubsystem()
+
+	if subsystem.ODataID != "/redfish/v1/Chassis/1/Power" {
+		t.Errorf("Invalid ODataID: %s", subsystem.ODataID)
+	}
+	if subsystem.CapacityWatts != 1000 {
+		t.Errorf("Invalid CapacityWatts: %g", subsystem.CapacityWatts)
+	}
+	if subsystem.Allocation.AllocatedWatts != 600 || subsystem.Allocation.RequestedWatts != 700 {
+		t.Errorf("Invalid Allocation: %+v", subsystem.Allocation)
+	}
+	if len(subsystem.PowerSupplyRedundancy) != 1 ||
+		subsystem.PowerSupplyRedundancy[0].RedundancyType != NPlusMRedundancyType ||
+		subsystem.PowerSupplyRedundancy[0].MinNeededInGroup != 1 {
+		t.Errorf("Invalid PowerSupplyRedundancy: %+v", subsystem.PowerSupplyRedundancy)
+	}
+	supplies, err := subsystem.PowerSupplies()
+	if err != nil {
+		t.Fatalf("Error getting power supplies: %s", err)
+	}
+	if len(supplies) != 2 || supplies[1].Name != "PSU2" {
+		t.Errorf("Invalid power supplies: %v", supplies)
+	}
+
+	roundTrip := subsystem.ToPower()
+
+	if len(roundTrip.PowerControl) != 1 {
+		t.Fatalf("Expected 1 power control, got %d", len(roundTrip.PowerControl))
+	}
+	control := roundTrip.PowerControl[0]
+	if control.PowerCapacityWatts != 1000 || control.PowerAllocatedWatts != 600 ||
+		control.PowerRequestedWatts != 700 || control.PowerAvailableWatts != 400 {
+		t.Errorf("Invalid power control: %+v", control)
+	}
+	if control.PowerConsumedWatts != 0 || control.PowerLimit.LimitInWatts != 0 {
+		t.Errorf("Readings and limits should be dropped: %+v", control)
+	}
+	if len(roundTrip.PowerSupplies) != 2 || roundTrip.PowerSuppliesCount != 2 {
+		t.Errorf("Invalid power supplies: %d", len(roundTrip.PowerSupplies))
+	}
+	if len(roundTrip.Redundancy) != 1 || roundTrip.Redundancy[0].Mode != NMRedundancyMode {
+		t.Errorf("Invalid redundancy: %+v", roundTrip.Redundancy)
+	}
+	if len(roundTrip.Voltages) != 0 {
+		t.Errorf("Voltages should be dropped: %d", len(roundTrip.Voltages))
+	}
+
+	// Groups of types Power has no mode for are kept without one
+	subsystem.PowerSupplyRedundancy = append(subsystem.PowerSupplyRedundancy, RedundantGroup{
+		MaxSupportedInGroup: 4,
+		MinNeededInGroup:    2,
+		RedundancyType:      "NPlusTwo",
+		Status:              common.Status{Health: common.OKHealth},
+	})
+	roundTrip = subsystem.ToPower()
+	if len(roundTrip.Redundancy) != 2 || roundTrip.RedundancyCount != 2 {
+		t.Fatalf("Expected the unknown redundancy group to be kept: %+v", roundTrip.Redundancy)
+	}
+	unknown := roundTrip.Redundancy[1]
+	if unknown.Mode != "" || unknown.MinNumNeeded != 2 || unknown.MaxNumSupported != 4 ||
+		unknown.Status.Health != common.OKHealth || unknown.MemberID != "1" {
+		t.Errorf("Invalid redundancy: %+v", unknown)
+	}
+}
+
+var powerSubsystemBody = `{
+	"@odata.type": "#PowerSubsystem.v1_1_0.PowerSubsystem",
+	"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem",
+	"Id": "PowerSubsystem",
+	"Name": "Power Subsystem for Chassis",
+	"CapacityWatts": 2000,
+	"Allocation": {
+		"RequestedWatts": 1500,
+		"AllocatedWatts": 1200
+	},
+	"PowerSupplyRedundancy": [
+		{
+			"RedundancyType": "Failover",
+			"MaxSupportedInGroup": 2,
+			"MinNeededInGroup": 1,
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}
+	],
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"PowerSupplies": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies"},
+	"Batteries": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries"}
+}`
+
+// TestGetPowerSubsystem tests getting a PowerSubsystem and its supplies.
+func TestGetPowerSubsystem(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1U/PowerSubsystem": powerSubsystemBody,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies": `{
+			"Members@odata.count": 2,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1"},
+				{"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay2"}
+			]
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/Batteries": `{
+			"Members@odata.count": 1,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1"}
+			]
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1": batteryBody,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1": `{
+			"@odata.type": "#PowerSupply.v1_5_0.PowerSupply",
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1",
+			"Id": "Bay1",
+			"Name": "Power Supply Bay 1",
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"PowerSupplyType": "AC",
+			"LineInputVoltageType": "AC120V",
+			"PowerCapacityWatts": 1000,
+			"Model": "RKS-440DC",
+			"SerialNumber": "3F1000001",
+			"Assembly": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Assembly"}
+		}`,
+	}}
+
+	result, err := GetPowerSubsystem(testClient, "/redfish/v1/Chassis/1U/PowerSubsystem")
+	if err != nil {
+		t.Fatalf("Error getting power subsystem: %s", err)
+	}
+	if result.CapacityWatts != 2000 || result.Allocation.AllocatedWatts != 1200 {
+		t.Errorf("Invalid capacity or allocation: %g, %+v", result.CapacityWatts, result.Allocation)
+	}
+	if len(result.PowerSupplyRedundancy) != 1 || result.PowerSupplyRedundancy[0].RedundancyType != FailoverRedundancyType {
+		t.Errorf("Invalid redundancy: %+v", result.PowerSupplyRedundancy)
+	}
+	if result.batteries != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries" {
+		t.Errorf("Invalid batteries link: %s", result.batteries)
+	}
+
+	supplies, err := result.PowerSupplies()
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 {
+		t.Errorf("Expected one failure for the missing supply, got: %v", err)
+	}
+	if len(supplies) != 1 || supplies[0].ID != "Bay1" || supplies[0].PowerCapacityWatts != 1000 {
+		t.Fatalf("Invalid supplies: %+v", supplies)
+	}
+	if supplies[0].assembly != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Assembly" {
+		t.Errorf("Invalid assembly link: %s", supplies[0].assembly)
+	}
+
+	batteries, err := result.Batteries()
+	if err != nil {
+		t.Errorf("Error getting batteries: %s", err)
+	}
+	if len(batteries) != 1 || batteries[0].ID != "Module1" {
+		t.Errorf("Invalid batteries: %+v", batteries)
+	}
+
+	if _, err := GetPowerSubsystem(testClient, "/redfish/v1/Chassis/2U/PowerSubsystem"); err == nil {
+		t.Error("Expected an error for a missing subsystem")
+	}
+}
+
+// TestChassisPowerSubsystemOnly tests a chassis that links only to a
+// PowerSubsystem, as newer services do, and not to a Power resource.
+func TestChassisPowerSubsystemOnly(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1U": `{
+			"@odata.type": "#Chassis.v1_15_0.Chassis",
+			"@odata.id": "/redfish/v1/Chassis/1U",
+			"Id": "1U",
+			"Name": "Computer System Chassis",
+			"ChassisType": "RackMount",
+			"PowerSubsystem": {"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem"}
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem": powerSubsystemBody,
+	}}
+
+	chassis, err := GetChassis(testClient, "/redfish/v1/Chassis/1U")
+	if err != nil {
+		t.Fatalf("Error getting chassis: %s", err)
+	}
+	if chassis.ID != "1U" {
+		t.Errorf("Invalid chassis ID: %s", chassis.ID)
+	}
+
+	if power, err := chassis.Power(); err != nil || power != nil {
+		t.Errorf("Expected no Power resource, got %v: %v", power, err)
+	}
+
+	subsystem, err := chassis.PowerSubsystem()
+	if err != nil {
+		t.Fatalf("Error getting power subsystem: %s", err)
+	}
+	if subsystem.CapacityWatts != 2000 {
+		t.Errorf("Invalid capacity: %g", subsystem.CapacityWatts)
+	}
+}
@@ -0,0 +1,116 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// PowerControlReading holds the consumption readings of one PowerControl.
+type PowerControlReading struct {
+	// MemberID is the MemberId of the PowerControl.
+	MemberID string
+	// PhysicalContext is the region the PowerControl applies to.
+	PhysicalContext common.PhysicalContext
+	// PowerConsumedWatts is the actual power being consumed.
+	PowerConsumedWatts float64
+	// PowerMetrics holds the minimum, maximum and average consumption.
+	PowerMetrics PowerMetric
+}
+
+// UnmarshalJSON unmarshals a PowerControlReading object from the raw JSON of a
+// PowerControl, accepting the same variations as PowerControl.
+func (reading *PowerControlReading) UnmarshalJSON(b []byte) error {
+	var t struct {
+		MemberID           json.RawMessage `json:"MemberId"`
+		PhysicalContext    string
+		PowerConsumedWatts json.RawMessage
+		PowerMetrics       PowerMetric
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	consumed, _, err := decodeConsumedWattsReading(t.PowerConsumedWatts)
+	if err != nil {
+		return err
+	}
+
+	// Some Dell implementations return MemberId as an integer
+	var memberID string
+	if json.Unmarshal(t.MemberID, &memberID) != nil {
+		var number int
+		if json.Unmarshal(t.MemberID, &number) == nil {
+			memberID = strconv.Itoa(number)
+		}
+	}
+
+	reading.MemberID = memberID
+	reading.PhysicalContext = NormalizePhysicalContext(t.PhysicalContext)
+	reading.PowerConsumedWatts = consumed
+	reading.PowerMetrics = t.PowerMetrics
+
+	return nil
+}
+
+// PowerSummary holds just the consumption readings of a Power resource, for
+// polling them without decoding the power supplies and voltages.
+type PowerSummary struct {
+	// ODataID is the URI of the Power resource.
+	ODataID string
+	// PowerControl holds the readings of each PowerControl.
+	PowerControl []PowerControlReading
+}
+
+// GetPowerSummary will get the consumption readings of a Power resource from
+// the service. The power supplies, voltages and other properties are skipped
+// rather than decoded, which makes it cheaper than GetPower for frequent
+// polling.
+func GetPowerSummary(c common.Client, uri string) (*PowerSummary, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &common.TransportError{URI: uri, Err: err}
+	}
+
+	var t struct {
+		ODataID      string          `json:"@odata.id"`
+		PowerControl json.RawMessage `json:"PowerControl"`
+	}
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return nil, &common.ParseError{URI: uri, Err: err}
+	}
+
+	summary := PowerSummary{ODataID: t.ODataID}
+
+	// As with GetPower, a single PowerControl object is accepted in place
+	// of an array
+	switch {
+	case len(t.PowerControl) == 0 || string(t.PowerControl) == "null":
+	case isJSONObject(t.PowerControl):
+		var reading PowerControlReading
+		err = json.Unmarshal(t.PowerControl, &reading)
+		summary.PowerControl = []PowerControlReading{reading}
+	default:
+		err = json.Unmarshal(t.PowerControl, &summary.PowerControl)
+	}
+	if err != nil {
+		return nil, &common.ParseError{URI: uri, Err: err}
+	}
+
+	return &summary, nil
+}
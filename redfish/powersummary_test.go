@@ -0,0 +1,100 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// TestGetPowerSummary tests reading just the consumption of a Power resource.
+func TestGetPowerSummary(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(mockupRackmountPowerBody)},
+		},
+	}
+
+	summary, err := GetPowerSummary(testClient, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power summary: %s", err)
+	}
+
+	power, err := GetPower(&common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(mockupRackmountPowerBody)},
+		},
+	}, "/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+
+	if summary.ODataID != power.ODataID {
+		t.Errorf("Invalid ODataID: %s", summary.ODataID)
+	}
+
+	if len(summary.PowerControl) != len(power.PowerControl) {
+		t.Fatalf("Expected %d readings, got %d", len(power.PowerControl), len(summary.PowerControl))
+	}
+
+	for i, reading := range summary.PowerControl {
+		control := power.PowerControl[i]
+		if reading.MemberID != control.MemberID ||
+			reading.PhysicalContext != control.PhysicalContext ||
+			reading.PowerConsumedWatts != control.PowerConsumedWatts ||
+			reading.PowerMetrics != control.PowerMetrics {
+			t.Errorf("Reading %d differs from GetPower: %+v, %+v", i, reading, control)
+		}
+	}
+}
+
+// TestGetPowerSummaryShapes tests the PowerControl variations GetPower accepts.
+func TestGetPowerSummaryShapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		memberID string
+		consumed float64
+	}{
+		{"object", `{"PowerControl": {"MemberId": "0", "PowerConsumedWatts": 344}}`, "0", 344},
+		{"numeric member", `{"PowerControl": [{"MemberId": 1, "PowerConsumedWatts": 120}]}`, "1", 120},
+		{"string watts", `{"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": "98.5"}]}`, "0", 98.5},
+		{"sensor excerpt", `{"PowerControl": [{"MemberId": "0", "PowerConsumedWatts": {"DataSourceUri": "/redfish/v1/Chassis/1/Sensors/TotalPower", "Reading": 344}}]}`, "0", 344},
+	}
+
+	for _, test := range tests {
+		testClient := &common.TestClient{
+			CustomReturnForActions: map[string][]interface{}{
+				http.MethodGet: {getCall(test.body)},
+			},
+		}
+
+		summary, err := GetPowerSummary(testClient, "/redfish/v1/Chassis/1/Power")
+		if err != nil {
+			t.Errorf("%s: error getting power summary: %s", test.name, err)
+			continue
+		}
+
+		if len(summary.PowerControl) != 1 ||
+			summary.PowerControl[0].MemberID != test.memberID ||
+			summary.PowerControl[0].PowerConsumedWatts != test.consumed {
+			t.Errorf("%s: invalid readings: %+v", test.name, summary.PowerControl)
+		}
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {getCall(`{"PowerControl": [{"PowerConsumedWatts": "lots"}]}`)},
+		},
+	}
+	_, err := GetPowerSummary(testClient, "/redfish/v1/Chassis/1/Power")
+	var parseErr *common.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a parse error, got: %v", err)
+	}
+}
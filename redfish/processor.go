@@ -456,7 +456,7 @@ func GetProcessor(c common.Client, uri string) (*Processor, error) {
         if err != nil {
                 fmt.Println("**************************processor json body 报错!", err)
         }else{
-                fmt.Println("**************************processor json body: 已获取\n")
+                fmt.Println("**************************processor json body: 已获取")
         }
 	file, _ := os.Create("/tmp/processorjson.txt")
         defer file.Close()
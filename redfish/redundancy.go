@@ -65,6 +65,12 @@ type Redundancy struct {
 	RedundancySetCount int `json:"RedundancySet@odata.count"`
 	// Status shall contain any status or health properties of the resource.
 	Status common.Status
+	// onlineMembers is the number of members of the group that are present
+	// and not failed, if membersKnown is set.
+	onlineMembers int
+	// membersKnown is set when the members of the group could be resolved,
+	// such as for the Redundancy of a Power resource.
+	membersKnown bool
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
@@ -113,6 +119,39 @@ func (redundancy *Redundancy) Update() error {
 	return redundancy.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
+// IsRedundancyLost reports whether the group is configured to be redundant
+// but can no longer tolerate a failure, even though it may still be
+// operating. When the online members of the group are known, as for the
+// Redundancy of a Power resource, it is lost once fewer of them than
+// MinNumNeeded are present and not failed. Otherwise a Warning or Critical
+// health of the group is taken to mean it is lost. A NotRedundant group
+// never loses redundancy.
+func (redundancy *Redundancy) IsRedundancyLost() bool {
+	if redundancy.Mode == NotRedundantRedundancyMode {
+		return false
+	}
+
+	if redundancy.membersKnown && redundancy.onlineMembers < redundancy.MinNumNeeded {
+		return true
+	}
+
+	switch redundancy.Status.Health {
+	case common.WarningHealth, common.CriticalHealth:
+		return true
+	}
+	return false
+}
+
+// IsHealthy reports whether the group reports no health problem and has not
+// lost its redundancy. A group that does not report its health is only
+// judged by its members.
+func (redundancy *Redundancy) IsHealthy() bool {
+	if redundancy.Status.Health != "" && redundancy.Status.Health != common.OKHealth {
+		return false
+	}
+	return !redundancy.IsRedundancyLost()
+}
+
 // GetRedundancy will get a Redundancy instance from the service.
 func GetRedundancy(c common.Client, uri string) (*Redundancy, error) {
 	resp, err := c.Get(uri)
@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -88,3 +89,87 @@ func TestRedundancyUpdate(t *testing.T) { // nolint:dupl
 		t.Errorf("Unexpected update for RedundancyEnabled in payload: %s", calls[0].Payload)
 	}
 }
+
+// redundantPowerBody builds a Power body with a redundancy group over the
+// given number of supplies, the first failed of which are failed.
+func redundantPowerBody(mode RedundancyMode, supplies, minNumNeeded, failed int, health common.Health) string {
+	var members, set []string
+	for i := 0; i < supplies; i++ {
+		supplyHealth := common.OKHealth
+		if i < failed {
+			supplyHealth = common.CriticalHealth
+		}
+		members = append(members, fmt.Sprintf(`{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/%d",
+			"MemberId": "%d",
+			"Status": {"State": "Enabled", "Health": "%s"}
+		}`, i, i, supplyHealth))
+		set = append(set, fmt.Sprintf(`{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/%d"}`, i))
+	}
+
+	return fmt.Sprintf(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerSupplies": [%s],
+		"Redundancy": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0",
+			"MemberId": "0",
+			"Name": "PowerSupply Redundancy Group 1",
+			"Mode": "%s",
+			"MaxNumSupported": %d,
+			"MinNumNeeded": %d,
+			"RedundancySet": [%s],
+			"Status": {"State": "Enabled", "Health": "%s"}
+		}]
+	}`, strings.Join(members, ","), mode, supplies, minNumNeeded, strings.Join(set, ","), health)
+}
+
+// TestRedundancyHealth tests evaluating redundancy groups against their
+// online members.
+func TestRedundancyHealth(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		healthy        bool
+		lostRedundancy bool
+	}{
+		// N+1: three supplies where two carry the load
+		{"N+1 all online", redundantPowerBody(NMRedundancyMode, 3, 3, 0, common.OKHealth), true, false},
+		{"N+1 one failed", redundantPowerBody(NMRedundancyMode, 3, 3, 1, common.OKHealth), false, true},
+		{"N+1 one failed, reported", redundantPowerBody(NMRedundancyMode, 3, 3, 1, common.WarningHealth), false, true},
+		// N+N: four supplies where two carry the load, fault tolerant with three
+		{"N+N all online", redundantPowerBody(NMRedundancyMode, 4, 3, 0, common.OKHealth), true, false},
+		{"N+N one failed", redundantPowerBody(NMRedundancyMode, 4, 3, 1, common.OKHealth), true, false},
+		{"N+N two failed", redundantPowerBody(NMRedundancyMode, 4, 3, 2, common.OKHealth), false, true},
+		{"N+N degraded", redundantPowerBody(NMRedundancyMode, 4, 3, 0, common.WarningHealth), false, true},
+		{"not redundant", redundantPowerBody(NotRedundantRedundancyMode, 1, 1, 1, common.OKHealth), true, false},
+	}
+
+	for _, test := range tests {
+		var power Power
+		if err := json.Unmarshal([]byte(test.body), &power); err != nil {
+			t.Fatalf("%s: error decoding JSON: %s", test.name, err)
+		}
+
+		redundancy := &power.Redundancy[0]
+		if redundancy.IsHealthy() != test.healthy {
+			t.Errorf("%s: expected IsHealthy %t", test.name, test.healthy)
+		}
+		if redundancy.IsRedundancyLost() != test.lostRedundancy {
+			t.Errorf("%s: expected IsRedundancyLost %t", test.name, test.lostRedundancy)
+		}
+	}
+
+	// A group on its own is judged by its health
+	var result Redundancy
+	if err := json.Unmarshal([]byte(redundancyBody), &result); err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	if !result.IsHealthy() || result.IsRedundancyLost() {
+		t.Errorf("Expected a healthy redundancy group")
+	}
+	result.Status.Health = common.WarningHealth
+	if result.IsHealthy() || !result.IsRedundancyLost() {
+		t.Errorf("Expected the redundancy group to be lost")
+	}
+}
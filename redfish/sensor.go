@@ -0,0 +1,270 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// ReadingType is the type of reading a sensor provides.
+type ReadingType string
+
+const (
+
+	// TemperatureReadingType The sensor measures temperature in degrees
+	// Celsius.
+	TemperatureReadingType ReadingType = "Temperature"
+	// HumidityReadingType The sensor measures relative humidity as a
+	// percentage.
+	HumidityReadingType ReadingType = "Humidity"
+	// PowerReadingType The sensor measures the arithmetic mean of product
+	// terms of instantaneous voltage and current values, in watts.
+	PowerReadingType ReadingType = "Power"
+	// EnergykWhReadingType The sensor measures energy in kilowatt-hours.
+	EnergykWhReadingType ReadingType = "EnergykWh"
+	// VoltageReadingType The sensor measures the electrical potential
+	// difference between two points, in volts.
+	VoltageReadingType ReadingType = "Voltage"
+	// CurrentReadingType The sensor measures the flow of electrical charge,
+	// in amperes.
+	CurrentReadingType ReadingType = "Current"
+	// FrequencyReadingType The sensor measures frequency in hertz.
+	FrequencyReadingType ReadingType = "Frequency"
+	// RotationalReadingType The sensor measures rotational speed in
+	// revolutions per minute.
+	RotationalReadingType ReadingType = "Rotational"
+	// AirFlowReadingType The sensor measures the volumetric flow of air, in
+	// cubic feet per minute.
+	AirFlowReadingType ReadingType = "AirFlow"
+	// PercentReadingType The sensor measures a percentage.
+	PercentReadingType ReadingType = "Percent"
+)
+
+// ThresholdActivation is the direction of crossing that activates a
+// threshold.
+type ThresholdActivation string
+
+const (
+
+	// IncreasingThresholdActivation Value increases above the threshold.
+	IncreasingThresholdActivation ThresholdActivation = "Increasing"
+	// DecreasingThresholdActivation Value decreases below the threshold.
+	DecreasingThresholdActivation ThresholdActivation = "Decreasing"
+	// EitherThresholdActivation Value crosses the threshold in either
+	// direction.
+	EitherThresholdActivation ThresholdActivation = "Either"
+)
+
+// Threshold shall contain the properties for an individual threshold for
+// this sensor.
+type Threshold struct {
+	// Activation shall indicate the direction of crossing of the reading for
+	// this sensor that activates the threshold.
+	Activation ThresholdActivation
+	// DwellTime shall indicate the duration the sensor value must violate
+	// the threshold before the threshold is activated.
+	DwellTime string
+	// Reading shall indicate the reading for this sensor that activates the
+	// threshold. The value of the property shall use the same units as the
+	// Reading property.
+	Reading float64
+	// reported records whether the service reported a Reading, since 0 is a
+	// valid threshold.
+	reported bool
+}
+
+// UnmarshalJSON unmarshals a Threshold object from the raw JSON.
+func (threshold *Threshold) UnmarshalJSON(b []byte) error {
+	type temp Threshold
+	var t struct {
+		temp
+		Reading *float64
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*threshold = Threshold(t.temp)
+	if t.Reading != nil {
+		threshold.Reading = *t.Reading
+		threshold.reported = true
+	}
+
+	return nil
+}
+
+// IsSet reports whether the service reported a Reading for this threshold.
+func (threshold *Threshold) IsSet() bool {
+	return threshold.reported
+}
+
+// reading returns the Reading of the threshold, or nil if it is not set.
+func (threshold *Threshold) reading() *float64 {
+	if !threshold.reported {
+		return nil
+	}
+	return &threshold.Reading
+}
+
+// Thresholds shall contain the set of thresholds that derive a sensor's
+// health and operational range.
+type Thresholds struct {
+	// LowerCaution shall contain the value at which the reading is below
+	// normal range.
+	LowerCaution Threshold
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerFatal shall contain the value at which the reading is below
+	// normal range and fatal.
+	LowerFatal Threshold
+	// UpperCaution shall contain the value at which the reading is above
+	// normal range.
+	UpperCaution Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperFatal shall contain the value at which the reading is above
+	// normal range and fatal.
+	UpperFatal Threshold
+}
+
+// SensorExcerpt shall contain a reading copied from a Sensor resource, such as
+// the readings embedded in battery and power resources.
+type SensorExcerpt struct {
+	// DataSourceURI shall contain a URI to the Sensor resource that provides
+	// the data for this excerpt.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float64
+}
+
+// Sensor gets the Sensor resource the excerpt was copied from, or nil if the
+// excerpt has no data source.
+func (excerpt *SensorExcerpt) Sensor(c common.Client) (*Sensor, error) {
+	if excerpt.DataSourceURI == "" {
+		return nil, nil
+	}
+
+	return GetSensor(c, excerpt.DataSourceURI)
+}
+
+// Sensor shall represent a sensor for a Redfish implementation, such as a
+// member of the Sensors collection of a chassis.
+type Sensor struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Accuracy shall contain the percent error +/- of the measured versus
+	// actual values of the Reading property.
+	Accuracy float64
+	// Description provides a description of this resource.
+	Description string
+	// PeakReading shall contain the peak sensor value since the last
+	// ResetMetrics action or service reset.
+	PeakReading float64
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+	// Precision shall contain the number of significant digits in the
+	// Reading property.
+	Precision float64
+	// Reading shall contain the sensor value.
+	Reading float64
+	// ReadingRangeMax shall indicate the maximum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMax float64
+	// ReadingRangeMin shall indicate the minimum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMin float64
+	// ReadingType shall contain the type of the sensor.
+	ReadingType ReadingType
+	// ReadingUnits shall contain the units of the sensor's reading and
+	// thresholds, such as "V" or "W".
+	ReadingUnits string
+	// SensorNumber is a numerical identifier for the sensor that is unique
+	// within the chassis. It is not part of the Sensor schema, but some
+	// services report it.
+	SensorNumber int
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Thresholds shall contain the set of thresholds that derive a sensor's
+	// health and operational range.
+	Thresholds Thresholds
+}
+
+// UnmarshalJSON unmarshals a Sensor object from the raw JSON.
+func (sensor *Sensor) UnmarshalJSON(b []byte) error {
+	type temp Sensor
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*sensor = Sensor(t.temp)
+	sensor.PhysicalContext = NormalizePhysicalContext(string(sensor.PhysicalContext))
+
+	return nil
+}
+
+// GetSensor will get a Sensor instance from the service.
+func GetSensor(c common.Client, uri string) (*Sensor, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+	defer resp.Body.Close()
+
+	var sensor Sensor
+	err = json.NewDecoder(resp.Body).Decode(&sensor)
+	if err != nil {
+		return nil, common.ClassifyError(uri, err)
+	}
+
+	sensor.SetClient(c)
+	return &sensor, nil
+}
+
+// ListReferencedSensors gets the collection of Sensor from
+// a provided reference.
+func ListReferencedSensors(c common.Client, link string) ([]*Sensor, error) { //nolint:dupl
+	var result []*Sensor
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, common.ClassifyError(link, err)
+	}
+
+	collectionError := common.NewCollectionError()
+	for _, sensorLink := range links.ItemLinks {
+		sensor, err := GetSensor(c, sensorLink)
+		if err != nil {
+			collectionError.Failures[sensorLink] = err
+		} else {
+			result = append(result, sensor)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,99 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+var sensorBody = `{
+	"@odata.type": "#Sensor.v1_2_0.Sensor",
+	"@odata.id": "/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage",
+	"Id": "PS1InputVoltage",
+	"Name": "Power Supply 1 Input Voltage",
+	"ReadingType": "Voltage",
+	"ReadingUnits": "V",
+	"Reading": 119.6,
+	"ReadingRangeMin": 0,
+	"ReadingRangeMax": 250,
+	"Accuracy": 1.5,
+	"Precision": 1,
+	"PhysicalContext": "Power Supply",
+	"Status": {"State": "Enabled", "Health": "OK"},
+	"Thresholds": {
+		"LowerCritical": {"Reading": 100, "Activation": "Decreasing"},
+		"LowerCaution": {"Reading": 0},
+		"UpperCritical": {"Reading": 130, "Activation": "Increasing", "DwellTime": "PT5S"}
+	}
+}`
+
+// TestSensor tests the parsing of Sensor objects.
+func TestSensor(t *testing.T) {
+	var result Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PS1InputVoltage" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ReadingType != VoltageReadingType || result.ReadingUnits != "V" || result.Reading != 119.6 {
+		t.Errorf("Invalid reading: %s %g %s", result.ReadingType, result.Reading, result.ReadingUnits)
+	}
+
+	if result.PhysicalContext != common.PowerSupplyPhysicalContext {
+		t.Errorf("Invalid physical context: %s", result.PhysicalContext)
+	}
+
+	thresholds := result.Thresholds
+	if !thresholds.LowerCritical.IsSet() || thresholds.LowerCritical.Reading != 100 ||
+		thresholds.LowerCritical.Activation != DecreasingThresholdActivation {
+		t.Errorf("Invalid LowerCritical threshold: %+v", thresholds.LowerCritical)
+	}
+	if thresholds.UpperCritical.DwellTime != "PT5S" {
+		t.Errorf("Invalid UpperCritical dwell time: %s", thresholds.UpperCritical.DwellTime)
+	}
+	if !thresholds.LowerCaution.IsSet() {
+		t.Error("A threshold of 0 should be reported as set")
+	}
+	if thresholds.UpperFatal.IsSet() {
+		t.Error("A missing threshold should not be reported as set")
+	}
+}
+
+// TestListReferencedSensors tests getting the sensors of a collection.
+func TestListReferencedSensors(t *testing.T) {
+	testClient := &uriClient{bodies: map[string]string{
+		"/redfish/v1/Chassis/1U/Sensors": `{
+			"Members@odata.count": 2,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage"},
+				{"@odata.id": "/redfish/v1/Chassis/1U/Sensors/Missing"}
+			]
+		}`,
+		"/redfish/v1/Chassis/1U/Sensors/PS1InputVoltage": sensorBody,
+	}}
+
+	result, err := ListReferencedSensors(testClient, "/redfish/v1/Chassis/1U/Sensors")
+	var collectionErr *common.CollectionError
+	if !errors.As(err, &collectionErr) || len(collectionErr.Failures) != 1 {
+		t.Errorf("Expected one failure for the missing sensor, got: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != "PS1InputVoltage" {
+		t.Errorf("Invalid sensors: %+v", result)
+	}
+
+	if result, err := ListReferencedSensors(testClient, ""); err != nil || len(result) != 0 {
+		t.Errorf("Expected no sensors without a link, got %v, %v", result, err)
+	}
+}
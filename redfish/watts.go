@@ -0,0 +1,81 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+// Watts is a power value that may not have been reported. Arithmetic on
+// Watts propagates absence, so a missing reading never silently counts as
+// zero in a sum.
+type Watts struct {
+	// Value is the power in watts. It is zero if Present is false.
+	Value float64
+	// Present is whether the value was reported.
+	Present bool
+}
+
+// NewWatts returns a present Watts value.
+func NewWatts(value float64) Watts {
+	return Watts{Value: value, Present: true}
+}
+
+// Add returns the sum of w and other, which is absent if either is absent.
+func (w Watts) Add(other Watts) Watts {
+	if !w.Present || !other.Present {
+		return Watts{}
+	}
+
+	return NewWatts(w.Value + other.Value)
+}
+
+// Sub returns w minus other, which is absent if either is absent.
+func (w Watts) Sub(other Watts) Watts {
+	if !w.Present || !other.Present {
+		return Watts{}
+	}
+
+	return NewWatts(w.Value - other.Value)
+}
+
+// Percent returns w as a percentage of of. False is returned if either value
+// is absent or of is not positive.
+func (w Watts) Percent(of Watts) (float64, bool) {
+	if !w.Present || !of.Present {
+		return 0, false
+	}
+
+	return percentOf(w.Value, of.Value)
+}
+
+// TotalConsumedWatts returns the summed consumption of the chassis power
+// domain, the first PowerControl, of each of powers. The result is absent if
+// powers is empty or any of them does not report its consumption.
+func TotalConsumedWatts(powers []*Power) Watts {
+	if len(powers) == 0 {
+		return Watts{}
+	}
+
+	total := NewWatts(0)
+	for _, power := range powers {
+		total = total.Add(power.chassisControl().ConsumedWatts())
+	}
+
+	return total
+}
+
+// TotalHeadroomWatts returns the summed unused capacity of the chassis power
+// domain of each of powers. The result is absent if powers is empty or any
+// of them does not report both its capacity and consumption.
+func TotalHeadroomWatts(powers []*Power) Watts {
+	if len(powers) == 0 {
+		return Watts{}
+	}
+
+	total := NewWatts(0)
+	for _, power := range powers {
+		control := power.chassisControl()
+		total = total.Add(control.CapacityWatts().Sub(control.ConsumedWatts()))
+	}
+
+	return total
+}
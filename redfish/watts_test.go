@@ -0,0 +1,68 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestWattsArithmetic tests that absent values propagate.
+func TestWattsArithmetic(t *testing.T) {
+	if sum := NewWatts(100).Add(NewWatts(50)); sum != NewWatts(150) {
+		t.Errorf("Invalid sum: %+v", sum)
+	}
+	if diff := NewWatts(100).Sub(NewWatts(30)); diff != NewWatts(70) {
+		t.Errorf("Invalid difference: %+v", diff)
+	}
+	if sum := NewWatts(100).Add(Watts{}); sum.Present {
+		t.Errorf("Sum with an absent value should be absent: %+v", sum)
+	}
+	if diff := (Watts{}).Sub(NewWatts(30)); diff.Present {
+		t.Errorf("Difference with an absent value should be absent: %+v", diff)
+	}
+
+	if pct, ok := NewWatts(50).Percent(NewWatts(200)); !ok || pct != 25 {
+		t.Errorf("Expected 25%%, got %g (%t)", pct, ok)
+	}
+	if _, ok := NewWatts(50).Percent(Watts{}); ok {
+		t.Error("Percent of an absent value should be absent")
+	}
+	if _, ok := NewWatts(50).Percent(NewWatts(0)); ok {
+		t.Error("Percent of zero should be absent")
+	}
+}
+
+// TestTotalConsumedWatts tests summing consumption across Power resources.
+func TestTotalConsumedWatts(t *testing.T) {
+	decode := func(body string) *Power {
+		var power Power
+		if err := json.Unmarshal([]byte(body), &power); err != nil {
+			t.Fatalf("Error decoding JSON: %s", err)
+		}
+		return &power
+	}
+
+	first := decode(`{"PowerControl": [{"MemberId": "0", "PowerCapacityWatts": 1000, "PowerConsumedWatts": 400}]}`)
+	second := decode(`{"PowerControl": [{"MemberId": "0", "PowerCapacityWatts": 800, "PowerConsumedWatts": 0}]}`)
+	missing := decode(`{"PowerControl": [{"MemberId": "0", "PowerCapacityWatts": 800, "PowerConsumedWatts": null}]}`)
+
+	if total := TotalConsumedWatts([]*Power{first, second}); total != NewWatts(400) {
+		t.Errorf("Invalid total: %+v", total)
+	}
+	if headroom := TotalHeadroomWatts([]*Power{first, second}); headroom != NewWatts(1400) {
+		t.Errorf("Invalid headroom: %+v", headroom)
+	}
+
+	if total := TotalConsumedWatts([]*Power{first, missing}); total.Present {
+		t.Errorf("Total with a missing reading should be absent: %+v", total)
+	}
+	if headroom := TotalHeadroomWatts([]*Power{first, missing, {}}); headroom.Present {
+		t.Errorf("Headroom with a missing reading should be absent: %+v", headroom)
+	}
+	if total := TotalConsumedWatts(nil); total.Present {
+		t.Errorf("Total of nothing should be absent: %+v", total)
+	}
+}
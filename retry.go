@@ -0,0 +1,156 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// RetryPolicy controls how an APIClient retries requests that fail with a
+// transient error: a retryable status code, or a connection failure such as
+// a reset. Each retry waits twice as long as the previous one, starting at
+// BaseDelay. Retries stop when the context of the client is done, or when
+// its deadline would pass before the next attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts for a request, including
+	// the first. Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction, from 0 to 1, by which each wait is randomly
+	// shortened, so that many clients do not retry in lockstep.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes to retry. If empty,
+	// 429, 502, 503 and 504 are retried.
+	RetryableStatusCodes []int
+	// Methods are the HTTP methods to retry. If empty, GET, HEAD, OPTIONS
+	// and PATCH requests are retried. POST requests, such as actions, are
+	// not retried by default since repeating them may not be safe.
+	Methods []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to three attempts,
+// waiting about 500ms and then 1s between them.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+var (
+	defaultRetryableStatusCodes = []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+	defaultRetryMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPatch,
+	}
+)
+
+// shouldRetry reports whether a request that failed with err on the given
+// attempt should be made again.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	methods := p.Methods
+	if len(methods) == 0 {
+		methods = defaultRetryMethods
+	}
+	if !containsString(methods, method) {
+		return false
+	}
+
+	var serviceErr *common.Error
+	if errors.As(err, &serviceErr) {
+		codes := p.RetryableStatusCodes
+		if len(codes) == 0 {
+			codes = defaultRetryableStatusCodes
+		}
+		for _, code := range codes {
+			if serviceErr.HTTPReturnedStatusCode == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Connection failures, such as resets and timeouts, are reported as
+	// network errors
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// delay returns the wait before the retry following the given attempt.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay)) // nolint:gosec
+	}
+
+	return delay
+}
+
+// wait sleeps before the retry following the given attempt. False is
+// returned, without waiting, if ctx would expire first, or if it is done
+// while waiting.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) bool {
+	delay := p.delay(attempt)
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ciferlu1024/gofish/common"
+)
+
+// flakyTransport fails the first failures requests, then succeeds.
+type flakyTransport struct {
+	mu       sync.Mutex
+	failures int
+	// status is returned for failed requests, or a connection reset if 0.
+	status   int
+	requests int
+	bodies   []string
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		t.bodies = append(t.bodies, string(body))
+	}
+
+	if t.requests <= t.failures {
+		if t.status == 0 {
+			return nil, syscall.ECONNRESET
+		}
+		return &http.Response{
+			StatusCode: t.status,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}, nil
+}
+
+func newRetryClient(ctx context.Context, transport http.RoundTripper, policy *RetryPolicy) *APIClient {
+	return &APIClient{
+		ctx:         ctx,
+		endpoint:    "http://bmc.example",
+		HTTPClient:  &http.Client{Transport: transport},
+		retryPolicy: policy,
+	}
+}
+
+// TestClientRetry tests retrying requests that fail with a transient error.
+func TestClientRetry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		method   string
+		failures int
+		status   int
+		requests int
+		fails    bool
+	}{
+		{"recovers from 503", http.MethodGet, 2, http.StatusServiceUnavailable, 3, false},
+		{"recovers from reset", http.MethodGet, 1, 0, 2, false},
+		{"patch recovers", http.MethodPatch, 3, http.StatusBadGateway, 4, false},
+		{"gives up", http.MethodGet, 4, http.StatusServiceUnavailable, 4, true},
+		{"not retryable status", http.MethodGet, 1, http.StatusNotFound, 1, true},
+		{"not retryable method", http.MethodPost, 1, http.StatusServiceUnavailable, 1, true},
+	}
+
+	for _, test := range tests {
+		transport := &flakyTransport{failures: test.failures, status: test.status}
+		client := newRetryClient(context.Background(), transport, policy)
+
+		var err error
+		switch test.method {
+		case http.MethodGet:
+			_, err = client.Get("/redfish/v1/Chassis/1/Power")
+		case http.MethodPatch:
+			_, err = client.Patch("/redfish/v1/Chassis/1/Power", map[string]string{"IndicatorLED": "Lit"})
+		case http.MethodPost:
+			_, err = client.Post("/redfish/v1/Chassis/1/Actions/Chassis.Reset", map[string]string{"ResetType": "On"})
+		}
+
+		if (err != nil) != test.fails {
+			t.Errorf("%s: unexpected result: %v", test.name, err)
+		}
+		if transport.requests != test.requests {
+			t.Errorf("%s: expected %d requests, got %d", test.name, test.requests, transport.requests)
+		}
+		for _, body := range transport.bodies {
+			if body != transport.bodies[0] {
+				t.Errorf("%s: expected the payload to be resent, got %q", test.name, transport.bodies)
+				break
+			}
+		}
+	}
+
+	var serviceErr *common.Error
+	transport := &flakyTransport{failures: 1, status: http.StatusServiceUnavailable}
+	_, err := newRetryClient(context.Background(), transport, nil).Get("/redfish/v1")
+	if !errors.As(err, &serviceErr) || transport.requests != 1 {
+		t.Errorf("Expected no retries without a policy, got %d requests: %v", transport.requests, err)
+	}
+}
+
+// TestClientRetryContext tests that retries stop at the context deadline.
+func TestClientRetryContext(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	transport := &flakyTransport{failures: 5, status: http.StatusServiceUnavailable}
+	client := newRetryClient(ctx, transport, policy)
+
+	start := time.Now()
+	_, err := client.Get("/redfish/v1/Chassis/1/Power")
+	if err == nil {
+		t.Error("Expected the request to fail")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Expected to give up before the deadline, took %s", time.Since(start))
+	}
+	if transport.requests != 1 {
+		t.Errorf("Expected no retry that would outlast the deadline, got %d requests", transport.requests)
+	}
+}
+
+// hangingTransport never answers, returning once the request is cancelled.
+type hangingTransport struct{}
+
+func (hangingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+// TestClientRequestTimeout tests bounding requests without a context
+// deadline, including their retries.
+func TestClientRequestTimeout(t *testing.T) {
+	client := newRetryClient(context.Background(), hangingTransport{}, nil)
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.Get("/redfish/v1/Chassis/1/Power")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to time out, got: %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Expected to give up at the timeout, took %s", time.Since(start))
+	}
+
+	// The timeout covers the retries as a whole
+	policy := &RetryPolicy{MaxAttempts: 10, BaseDelay: 20 * time.Millisecond}
+	transport := &flakyTransport{failures: 10, status: http.StatusServiceUnavailable}
+	client = newRetryClient(context.Background(), transport, policy)
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	if _, err := client.Get("/redfish/v1/Chassis/1/Power"); err == nil {
+		t.Error("Expected the request to fail")
+	}
+	if transport.requests < 2 || transport.requests >= 10 {
+		t.Errorf("Expected the retries to stop at the timeout, got %d requests", transport.requests)
+	}
+
+	// The body can be read after the request returns
+	etag := &etagTransport{body: `{"Name": "Power"}`}
+	client = newRetryClient(context.Background(), etag, nil)
+	client.SetRequestTimeout(time.Minute)
+
+	resp, err := client.Get("/redfish/v1/Chassis/1/Power")
+	if err != nil {
+		t.Fatalf("Error getting power: %s", err)
+	}
+	if etag.requests[0].Context().Err() != nil {
+		t.Error("Expected the request to stay open until the body is closed")
+	}
+	if body := readBody(t, resp); body != etag.body {
+		t.Errorf("Invalid body: %s", body)
+	}
+	if etag.requests[0].Context().Err() == nil {
+		t.Error("Expected closing the body to end the request")
+	}
+}
+
+// TestRetryPolicyDelay tests the exponential backoff.
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, delay := range expected {
+		if policy.delay(i+1) != delay {
+			t.Errorf("Attempt %d: expected %s, got %s", i+1, delay, policy.delay(i+1))
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := policy.delay(2)
+		if delay < 100*time.Millisecond || delay > 200*time.Millisecond {
+			t.Errorf("Jittered delay out of range: %s", delay)
+		}
+	}
+}